@@ -1,17 +1,28 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/config"
 	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/repository/psql"
 	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/service"
+	grpc_client "github.com/AngelicaNice/HollywoodStarsCRUD/internal/transport/grpc"
+	grpc_server "github.com/AngelicaNice/HollywoodStarsCRUD/internal/transport/grpc/server"
 	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/transport/mq"
 	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/transport/rest"
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/transport/sink"
 	hash "github.com/AngelicaNice/HollywoodStarsCRUD/pkg"
 	database "github.com/AngelicaNice/HollywoodStarsCRUD/pkg/database"
+	"github.com/AngelicaNice/HollywoodStarsCRUD/pkg/jwtkeys"
 
 	log "github.com/sirupsen/logrus"
 
@@ -21,6 +32,8 @@ import (
 const (
 	CONFIG_DIR  = "configs"
 	CONFIG_FILE = "main"
+
+	shutdownTimeout = 10 * time.Second
 )
 
 func init() {
@@ -59,41 +72,211 @@ func main() {
 	}
 	defer db.Close()
 
+	transactor := psql.NewTransactor(db)
+	outbox := psql.NewOutbox(db)
+
 	actorsRepo := psql.NewActors(db)
-	actorsService := service.NewActors(actorsRepo)
+	actorsService := service.NewActors(actorsRepo, outbox, transactor)
 
-	hasher := hash.NewSHA1Hasher("salt")
+	moviesRepo := psql.NewMovies(db)
+	moviesService := service.NewMovies(moviesRepo)
+
+	hasher := hash.NewArgon2Hasher(hash.DefaultArgon2Params, hash.NewSHA1Hasher("salt"))
 
 	usersRepo := psql.NewUsers(db)
 	tokensRepo := psql.NewTokens(db)
 
-	amqpConn, err := mq.CreateMQConnection(cfg.MQ.URL)
+	healthChecks := make(map[string]rest.HealthChecker)
+
+	auditSink, closeSink, err := newAuditSink(cfg, healthChecks)
 	if err != nil {
-		log.WithField("rabbitmq", "failed to connect").Fatal(err)
+		log.WithField("audit", "failed to create sink").Fatal(err)
 	}
+	defer closeSink()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// workers are the background goroutines using the db and the broker, they
+	// are waited for before the deferred closes run.
+	var workers sync.WaitGroup
+
+	relay := service.NewOutboxRelay(outbox, auditSink, cfg.Audit.Source, cfg.Outbox.Interval, cfg.Outbox.BatchSize)
+	workers.Add(1)
 
-	defer amqpConn.Close()
+	go func() {
+		defer workers.Done()
+		relay.Run(ctx)
+	}()
 
-	auditPublisher, err := mq.NewAuditPublisher(cfg, amqpConn)
+	purger := service.NewTrashPurger(actorsService, cfg.Trash.Retention, cfg.Trash.PurgeInterval, cfg.Trash.BatchSize)
+	workers.Add(1)
+
+	go func() {
+		defer workers.Done()
+		purger.Run(ctx)
+	}()
+
+	followsRepo := psql.NewFollows(db)
+	followsService := service.NewFollows(followsRepo, outbox, transactor)
+
+	tokenKeys, err := loadTokenKeys(cfg)
 	if err != nil {
-		log.WithField("rabbitmq", "failed to open a channel").Fatal(err)
+		log.WithField("auth", "failed to load signing keys").Fatal(err)
 	}
 
-	defer auditPublisher.CloseChan()
+	usersService := service.NewUsers(usersRepo, tokensRepo, outbox, transactor,
+		hasher, tokenKeys, cfg.Auth.TokenTtl)
 
-	usersService := service.NewUsers(usersRepo, tokensRepo, auditPublisher,
-		hasher, []byte("sample secret"), cfg.Auth.TokenTtl)
-
-	handler := rest.NewHandler(actorsService, usersService)
+	handler := rest.NewHandler(actorsService, moviesService, followsService, usersService, healthChecks)
 
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.Server.Port),
 		Handler: handler.InitRouter(),
 	}
 
-	log.Info("SERVER STARTED")
+	grpcSrv := grpc_server.NewServer(actorsService, usersService).Register()
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPC.Port))
+	if err != nil {
+		log.WithField("grpc", "failed to listen").Fatal(err)
+	}
+
+	go func() {
+		log.Info("GRPC SERVER STARTED")
+
+		if err := grpcSrv.Serve(lis); err != nil {
+			log.WithField("grpc", "server stopped").Fatal(err)
+		}
+	}()
+
+	if cfg.MQ.Import.Enabled {
+		consumer := mq.NewActorsConsumer(cfg, actorsService)
+		workers.Add(1)
 
-	if err := srv.ListenAndServe(); err != nil {
-		log.Fatal(err)
+		go func() {
+			defer workers.Done()
+			consumer.Run(ctx)
+		}()
 	}
+
+	go func() {
+		log.Info("SERVER STARTED")
+
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	}()
+
+	<-ctx.Done()
+
+	log.Info("SHUTTING DOWN")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.WithField("server", "failed to shut down").Error(err)
+	}
+
+	grpcSrv.GracefulStop()
+	workers.Wait()
+}
+
+func loadTokenKeys(cfg *config.Config) (*jwtkeys.KeySet, error) {
+	if len(cfg.Auth.Keys) == 0 {
+		if !cfg.Auth.EphemeralKey {
+			return nil, errors.New("no signing keys in auth.keys, generate one with make keys")
+		}
+
+		log.Warn("no signing keys configured, tokens won't survive a restart")
+
+		key, err := jwtkeys.GenerateEd25519Key("ephemeral")
+		if err != nil {
+			return nil, err
+		}
+
+		return jwtkeys.NewKeySet(key.ID, key)
+	}
+
+	keys := make([]jwtkeys.Key, 0, len(cfg.Auth.Keys))
+
+	for _, k := range cfg.Auth.Keys {
+		key, err := jwtkeys.LoadKey(k.ID, k.Alg, k.PrivateKeyFile, k.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return jwtkeys.NewKeySet(cfg.Auth.SigningKeyID, keys...)
+}
+
+// newAuditSink creates the sinks listed in the audit section of the config,
+// registers health checks of those that have them and returns a function
+// closing them.
+func newAuditSink(cfg *config.Config, healthChecks map[string]rest.HealthChecker) (service.AuditSink, func(), error) {
+	sinks := make(sink.Fanout, 0, len(cfg.Audit.Sinks))
+	closers := make([]func(), 0, len(cfg.Audit.Sinks))
+
+	closeAll := func() {
+		for _, c := range closers {
+			c()
+		}
+	}
+
+	for _, name := range cfg.Audit.Sinks {
+		switch name {
+		case "amqp":
+			publisher := mq.NewAuditPublisher(cfg)
+			sinks = append(sinks, publisher)
+			closers = append(closers, publisher.Close)
+			healthChecks["rabbitmq"] = publisher
+		case "grpc":
+			client, err := grpc_client.NewClient(cfg.Audit.GRPCPort)
+			if err != nil {
+				closeAll()
+
+				return nil, nil, err
+			}
+
+			sinks = append(sinks, client)
+			closers = append(closers, func() {
+				if err := client.CloseConnnection(); err != nil {
+					log.WithField("audit", "failed to close grpc connection").Error(err)
+				}
+			})
+		case "file":
+			file, err := sink.NewFile(cfg.Audit.File, cfg.Audit.Format == config.AuditFormatLegacy)
+			if err != nil {
+				closeAll()
+
+				return nil, nil, err
+			}
+
+			sinks = append(sinks, file)
+			closers = append(closers, func() {
+				if err := file.Close(); err != nil {
+					log.WithField("audit", "failed to close file").Error(err)
+				}
+			})
+		case "noop":
+		default:
+			closeAll()
+
+			return nil, nil, fmt.Errorf("unknown audit sink %q", name)
+		}
+	}
+
+	switch len(sinks) {
+	case 0:
+		log.Warn("no audit sinks configured, audit events are discarded")
+
+		return sink.Noop{}, closeAll, nil
+	case 1:
+		return sinks[0], closeAll, nil
+	}
+
+	return sinks, closeAll, nil
 }
@@ -17,18 +17,80 @@ type Postgres struct {
 	Password string
 }
 
+// SigningKey is a key access tokens are signed or verified with. A key with
+// only a public key file is used to verify tokens issued before a rotation.
+type SigningKey struct {
+	ID             string `mapstructure:"kid"`
+	Alg            string `mapstructure:"alg"`
+	PrivateKeyFile string `mapstructure:"private_key_file"`
+	PublicKeyFile  string `mapstructure:"public_key_file"`
+}
+
+// MQTopology is the exchanges and queues audit messages are routed through.
+// The queue MQ.Name is bound to Exchange with BindingKey; messages rejected by
+// a consumer or not consumed within MessageTTL go to the dead-letter queue.
+type MQTopology struct {
+	Exchange     string        `mapstructure:"exchange"`
+	ExchangeType string        `mapstructure:"exchange_type"`
+	BindingKey   string        `mapstructure:"binding_key"`
+	MessageTTL   time.Duration `mapstructure:"message_ttl"`
+	DeadLetter   struct {
+		Exchange string        `mapstructure:"exchange"`
+		Queue    string        `mapstructure:"queue"`
+		TTL      time.Duration `mapstructure:"ttl"`
+	} `mapstructure:"dead_letter"`
+}
+
+// MQImport is the queue actors are imported from and its dead-letter queue.
+type MQImport struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	Queue      string `mapstructure:"queue"`
+	Workers    int    `mapstructure:"workers"`
+	DeadLetter struct {
+		Exchange string `mapstructure:"exchange"`
+		Queue    string `mapstructure:"queue"`
+	} `mapstructure:"dead_letter"`
+}
+
+const AuditFormatLegacy = "legacy"
+
 type Config struct {
 	DB     Postgres
 	Server struct {
 		Port int `mapstructure:"port"`
 	} `mapstructure:"server"`
+	GRPC struct {
+		Port int `mapstructure:"port"`
+	} `mapstructure:"grpc"`
 	Auth struct {
-		TokenTtl time.Duration `mapstructure:"token_ttl"`
+		TokenTtl     time.Duration `mapstructure:"token_ttl"`
+		SigningKeyID string        `mapstructure:"signing_key_id"`
+		Keys         []SigningKey  `mapstructure:"keys"`
+		EphemeralKey bool          `mapstructure:"ephemeral_key"`
 	} `mapstructure:"auth"`
 	MQ struct {
-		URL  string `mapstructure:"url"`
-		Name string `mapstructure:"name"`
+		URL            string        `mapstructure:"url"`
+		Name           string        `mapstructure:"name"`
+		ConfirmTimeout time.Duration `mapstructure:"confirm_timeout"`
+		Topology       MQTopology    `mapstructure:"topology"`
+		Import         MQImport      `mapstructure:"import"`
 	} `mapstructure:"mq"`
+	Audit struct {
+		Sinks    []string `mapstructure:"sinks"`
+		Format   string   `mapstructure:"format"`
+		Source   string   `mapstructure:"source"`
+		File     string   `mapstructure:"file"`
+		GRPCPort int      `mapstructure:"grpc_port"`
+	} `mapstructure:"audit"`
+	Outbox struct {
+		Interval  time.Duration `mapstructure:"interval"`
+		BatchSize int           `mapstructure:"batch_size"`
+	} `mapstructure:"outbox"`
+	Trash struct {
+		Retention     time.Duration `mapstructure:"retention"`
+		PurgeInterval time.Duration `mapstructure:"purge_interval"`
+		BatchSize     int           `mapstructure:"batch_size"`
+	} `mapstructure:"trash"`
 }
 
 func NewConfig(folder string, filename string) (*Config, error) {
@@ -2,9 +2,18 @@ package domain
 
 import (
 	"errors"
+	"reflect"
+	"time"
 )
 
-var ErrActorNotFound = errors.New("actor not found")
+var (
+	ErrActorNotFound = errors.New("actor not found")
+	ErrActorExists   = errors.New("actor with this name, surname and birth year already exists")
+	ErrActorModified = errors.New("actor was modified, version doesn't match")
+)
+
+// AnyVersion is the version to pass to the writes that don't check it.
+const AnyVersion int64 = 0
 
 type Actor struct {
 	ID         int64   `json:"id"`
@@ -15,22 +24,109 @@ type Actor struct {
 	BirthPlace string  `json:"birth_place"`
 	RestYear   *int    `json:"rest_year"`
 	Language   *string `json:"language"`
+	Version    int64   `json:"-"`
+}
+
+// Input returns the fields of the actor that can be set.
+func (a Actor) Input() ActorInput {
+	return ActorInput{
+		Name:       a.Name,
+		Surname:    a.Surname,
+		Sex:        a.Sex,
+		BirthYear:  a.BirthYear,
+		BirthPlace: a.BirthPlace,
+		RestYear:   a.RestYear,
+		Language:   a.Language,
+	}
 }
 
 type ActorInput struct {
-	Name       string  `json:"name"`
-	Surname    string  `json:"surname"`
-	Sex        string  `json:"sex"`
-	BirthYear  int     `json:"birth_year"`
-	BirthPlace string  `json:"birth_place"`
-	RestYear   *int    `json:"rest_year"`
-	Language   *string `json:"language"`
+	Name       string  `json:"name" validate:"required,max=20"`
+	Surname    string  `json:"surname" validate:"required,max=20"`
+	Sex        string  `json:"sex" validate:"required,oneof=male female"`
+	BirthYear  int     `json:"birth_year" validate:"required,gte=1,notfuture"`
+	BirthPlace string  `json:"birth_place" validate:"required,max=15"`
+	RestYear   *int    `json:"rest_year" validate:"omitempty,gtefield=BirthYear,notfuture"`
+	Language   *string `json:"language" validate:"omitempty,max=15,iso639"`
 }
 
+// Validate returns a *ValidationError listing the invalid fields.
+func (input ActorInput) Validate() error {
+	return validateStruct(input)
+}
+
+// ValidateChanged validates only the fields that differ from old, so values
+// stored before a rule was added don't block changing the other fields.
+func (input ActorInput) ValidateChanged(old ActorInput) error {
+	inputValue, oldValue := reflect.ValueOf(input), reflect.ValueOf(old)
+
+	return validateStructChanged(input, func(field string) bool {
+		return !reflect.DeepEqual(inputValue.FieldByName(field).Interface(), oldValue.FieldByName(field).Interface())
+	})
+}
+
+// UpdateActorInfo holds the fields to change, nil fields are left as is.
+// Validate can't check rest_year against the birth year, the service does it
+// once the actor is loaded.
 type UpdateActorInfo struct {
-	Name     *string `json:"name"`
-	Surname  *string `json:"surname"`
-	Sex      *string `json:"sex"`
-	RestYear *int    `json:"rest_year"`
-	Language *string `json:"language"`
+	Name     *string `json:"name" validate:"omitempty,min=1,max=20"`
+	Surname  *string `json:"surname" validate:"omitempty,min=1,max=20"`
+	Sex      *string `json:"sex" validate:"omitempty,oneof=male female"`
+	RestYear *int    `json:"rest_year" validate:"omitempty,gte=1,notfuture"`
+	Language *string `json:"language" validate:"omitempty,max=15,iso639"`
+}
+
+// Validate returns a *ValidationError listing the invalid fields.
+func (info UpdateActorInfo) Validate() error {
+	return validateStruct(info)
+}
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+const (
+	DefaultActorsLimit = 20
+	MaxActorsLimit     = 100
+)
+
+type ActorsListOptions struct {
+	Sex           *string `form:"sex" validate:"omitempty,max=6"`
+	BirthYearFrom *int    `form:"birth_year_from" validate:"omitempty,gte=0"`
+	BirthYearTo   *int    `form:"birth_year_to" validate:"omitempty,gte=0"`
+	BirthPlace    *string `form:"birth_place" validate:"omitempty,max=15"`
+	Language      *string `form:"language" validate:"omitempty,max=15"`
+	Retired       *bool   `form:"retired"`
+	Sort          string  `form:"sort" validate:"omitempty,oneof=id name surname birth_year"`
+	Order         string  `form:"order" validate:"omitempty,oneof=asc desc"`
+	Limit         int     `form:"limit" validate:"omitempty,gte=1,lte=100"`
+	Cursor        string  `form:"cursor"`
+}
+
+func (opts ActorsListOptions) Validate() error {
+	return validateStruct(opts)
+}
+
+type ActorsPage struct {
+	Actors     []Actor `json:"actors"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+type ActorsSearchInput struct {
+	Query string `form:"q" validate:"required,max=100"`
+	Limit int    `form:"limit" validate:"omitempty,gte=1,lte=100"`
+}
+
+func (input ActorsSearchInput) Validate() error {
+	return validateStruct(input)
+}
+
+type ActorSearchResult struct {
+	Actor
+	Rank float64 `json:"rank"`
+}
+
+// DeletedActor is an actor in the trash, it's purged once it has been there
+// for the retention period.
+type DeletedActor struct {
+	Actor
+	DeletedAt time.Time `json:"deleted_at"`
 }
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"context"
+)
+
+type ctxKey int
+
+const (
+	ctxUserID ctxKey = iota
+	ctxRole
+	ctxTokenClaims
+)
+
+// WithUserID returns a copy of ctx carrying the id of the authenticated user.
+func WithUserID(ctx context.Context, id int64) context.Context {
+	return context.WithValue(ctx, ctxUserID, id)
+}
+
+// UserIDFromContext returns the id of the authenticated user stored by WithUserID.
+func UserIDFromContext(ctx context.Context) (int64, bool) {
+	id, ok := ctx.Value(ctxUserID).(int64)
+
+	return id, ok
+}
+
+// WithRole returns a copy of ctx carrying the role of the authenticated user.
+func WithRole(ctx context.Context, role Role) context.Context {
+	return context.WithValue(ctx, ctxRole, role)
+}
+
+// RoleFromContext returns the role of the authenticated user stored by WithRole.
+func RoleFromContext(ctx context.Context) (Role, bool) {
+	role, ok := ctx.Value(ctxRole).(Role)
+
+	return role, ok
+}
+
+// WithTokenClaims returns a copy of ctx carrying the claims of the access token
+// the request was authenticated with.
+func WithTokenClaims(ctx context.Context, claims TokenClaims) context.Context {
+	return context.WithValue(ctx, ctxTokenClaims, claims)
+}
+
+// TokenClaimsFromContext returns the claims stored by WithTokenClaims.
+func TokenClaimsFromContext(ctx context.Context) (TokenClaims, bool) {
+	claims, ok := ctx.Value(ctxTokenClaims).(TokenClaims)
+
+	return claims, ok
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const CloudEventsSpecVersion = "1.0"
+
+// Event is an audit event in the CloudEvents 1.0 model. Marshalled to JSON it
+// is the structured mode representation of the event.
+type Event struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+
+	// RoutingKey is not an attribute of the event, brokers route it with.
+	RoutingKey string `json:"-"`
+}
+
+// FieldChange is the value of a field before and after a change, nil for
+// a field of a created or deleted entity.
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"time"
+)
+
+type FollowedActor struct {
+	Actor
+	FollowedAt time.Time `json:"followed_at"`
+}
+
+type FollowersCount struct {
+	ActorID int64 `json:"actor_id"`
+	Count   int64 `json:"count"`
+}
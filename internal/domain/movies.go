@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+)
+
+var (
+	ErrMovieNotFound = errors.New("movie not found")
+	ErrRoleNotFound  = errors.New("role not found")
+	ErrRoleExists    = errors.New("role already exists")
+)
+
+type Movie struct {
+	ID          int64    `json:"id"`
+	Title       string   `json:"title"`
+	ReleaseYear int      `json:"release_year"`
+	Genres      []string `json:"genres"`
+	Runtime     *int     `json:"runtime"`
+	Studio      *string  `json:"studio"`
+}
+
+type MovieInput struct {
+	Title       string   `json:"title" validate:"required,max=100"`
+	ReleaseYear int      `json:"release_year" validate:"required,gte=1870"`
+	Genres      []string `json:"genres" validate:"dive,required,max=30"`
+	Runtime     *int     `json:"runtime" validate:"omitempty,gte=1"`
+	Studio      *string  `json:"studio" validate:"omitempty,max=50"`
+}
+
+func (input MovieInput) Validate() error {
+	return validateStruct(input)
+}
+
+type UpdateMovieInfo struct {
+	Title       *string   `json:"title" validate:"omitempty,max=100"`
+	ReleaseYear *int      `json:"release_year" validate:"omitempty,gte=1870"`
+	Genres      *[]string `json:"genres" validate:"omitempty,dive,required,max=30"`
+	Runtime     *int      `json:"runtime" validate:"omitempty,gte=1"`
+	Studio      *string   `json:"studio" validate:"omitempty,max=50"`
+}
+
+func (input UpdateMovieInfo) Validate() error {
+	return validateStruct(input)
+}
+
+type ActorRole struct {
+	ID            int64  `json:"id"`
+	ActorID       int64  `json:"actor_id"`
+	MovieID       int64  `json:"movie_id"`
+	CharacterName string `json:"character_name"`
+	BillingOrder  *int   `json:"billing_order"`
+	RoleType      string `json:"role_type"`
+}
+
+type RoleInput struct {
+	ActorID       int64  `json:"actor_id" validate:"required,gte=1"`
+	CharacterName string `json:"character_name" validate:"required,max=100"`
+	BillingOrder  *int   `json:"billing_order" validate:"omitempty,gte=1"`
+	RoleType      string `json:"role_type" validate:"required,oneof=lead supporting cameo voice"`
+}
+
+func (input RoleInput) Validate() error {
+	return validateStruct(input)
+}
+
+// FilmographyItem is a role of an actor together with the movie it was played in.
+type FilmographyItem struct {
+	ActorRole
+	Title       string `json:"title"`
+	ReleaseYear int    `json:"release_year"`
+}
+
+// CastMember is a role in a movie together with the actor who played it.
+type CastMember struct {
+	ActorRole
+	Name    string `json:"name"`
+	Surname string `json:"surname"`
+}
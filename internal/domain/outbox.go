@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"time"
+)
+
+// OutboxMessage is a message stored in the same transaction as the change it
+// describes, waiting to be published.
+type OutboxMessage struct {
+	ID         int64
+	RoutingKey string
+	Subject    string
+	Payload    []byte
+	Attempts   int
+	CreatedAt  time.Time
+}
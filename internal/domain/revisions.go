@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// Actions of the actor revisions. The history of the actors that existed
+// before revisions were kept starts with a baseline revision.
+const (
+	RevisionBaseline = "baseline"
+	RevisionCreate   = "create"
+	RevisionUpdate   = "update"
+	RevisionDelete   = "delete"
+	RevisionRestore  = "restore"
+	RevisionRevert   = "revert"
+)
+
+// ActorRevision is the full state of an actor after a change. Revision is the
+// version of the actor the change produced, UserID is nil for the changes not
+// made by a user, e.g. imports from the queue.
+type ActorRevision struct {
+	ActorID   int64      `json:"actor_id"`
+	Revision  int64      `json:"revision"`
+	Action    string     `json:"action"`
+	UserID    *int64     `json:"user_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	Actor     ActorInput `json:"actor"`
+}
+
+type RevisionsDiffInput struct {
+	From int64 `form:"from" validate:"required,gte=1"`
+	To   int64 `form:"to" validate:"required,gte=1"`
+}
+
+func (input RevisionsDiffInput) Validate() error {
+	return validateStruct(input)
+}
+
+// ActorRevisionsDiff lists the fields of the actor that differ between two
+// revisions.
+type ActorRevisionsDiff struct {
+	ActorID int64                  `json:"actor_id"`
+	From    int64                  `json:"from"`
+	To      int64                  `json:"to"`
+	Changes map[string]FieldChange `json:"changes"`
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrForbidden = errors.New("not enough permissions")
+
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+var roleLevels = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+// Allows reports whether the role grants everything the required role does:
+// viewers may read, editors may also create and update, admins may do anything.
+func (r Role) Allows(required Role) bool {
+	level, ok := roleLevels[r]
+
+	return ok && level >= roleLevels[required]
+}
+
+type UpdateRoleInput struct {
+	Role Role `json:"role" validate:"required,oneof=viewer editor admin"`
+}
+
+func (input UpdateRoleInput) Validate() error {
+	return validateStruct(input)
+}
+
+// TokenClaims is what an access token says about its bearer.
+type TokenClaims struct {
+	UserID    int64
+	Role      Role
+	TokenID   string
+	SessionID string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrAccessTokenRevoked  = errors.New("access token is revoked")
+	ErrUnauthenticatedUser = errors.New("unauthenticated user")
+)
+
+// Session is a signed in device. It lives as long as its refresh token family.
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     int64     `json:"-"`
+	Device     *string   `json:"device"`
+	IP         *string   `json:"ip"`
+	UserAgent  *string   `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	Current    bool      `json:"current"`
+}
+
+// ClientInfo describes where a token request came from.
+type ClientInfo struct {
+	Device    string
+	IP        string
+	UserAgent string
+}
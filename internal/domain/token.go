@@ -5,11 +5,23 @@ import (
 	"time"
 )
 
-var ErrRefreshTokenExpired = errors.New("refresh token is expired")
+var (
+	ErrRefreshTokenExpired  = errors.New("refresh token is expired")
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRefreshTokenRevoked  = errors.New("refresh token is revoked")
+	ErrRefreshTokenReused   = errors.New("refresh token is reused")
+)
 
+// RefreshToken is stored by the hash of the token only. Tokens issued one
+// after another by rotation share a FamilyID; RotatedAt is set once the token
+// was exchanged for its successor.
 type RefreshToken struct {
 	Id        int64
 	UserId    int64
 	Token     string
+	FamilyID  string
 	ExpiresAt time.Time
+	CreatedAt time.Time
+	RotatedAt *time.Time
+	RevokedAt *time.Time
 }
@@ -13,6 +13,7 @@ var ErrUserNotFound = errors.New("user not found")
 
 func init() {
 	validate = validator.New()
+	registerValidations(validate)
 }
 
 type User struct {
@@ -20,6 +21,7 @@ type User struct {
 	Nickname      string    `json:"nickname"`
 	Email         string    `json:"email"`
 	Password      string    `json:"password"`
+	Role          Role      `json:"role"`
 	Registered_at time.Time `json:"registered_at"`
 }
 
@@ -30,14 +32,15 @@ type SignUpInput struct {
 }
 
 func (input SignUpInput) Validate() error {
-	return validate.Struct(input)
+	return validateStruct(input)
 }
 
 type SignInInput struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,gte=6"`
+	Device   string `json:"device" validate:"omitempty,max=50"`
 }
 
 func (input SignInInput) Validate() error {
-	return validate.Struct(input)
+	return validateStruct(input)
 }
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/text/language"
+)
+
+// FieldError describes a field that failed validation, Field is the JSON
+// name of the field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists every field of an input that failed validation.
+type ValidationError struct {
+	Fields []FieldError `json:"errors"`
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Field+": "+f.Message)
+	}
+
+	return "validation failed: " + strings.Join(msgs, "; ")
+}
+
+func registerValidations(v *validator.Validate) {
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		return jsonName(f)
+	})
+
+	_ = v.RegisterValidation("notfuture", func(fl validator.FieldLevel) bool {
+		return fl.Field().Int() <= int64(time.Now().Year())
+	})
+
+	_ = v.RegisterValidation("iso639", func(fl validator.FieldLevel) bool {
+		_, err := language.ParseBase(fl.Field().String())
+
+		return err == nil
+	})
+}
+
+// validateStruct validates input and converts the validator errors to a
+// *ValidationError.
+func validateStruct(input interface{}) error {
+	return validateStructChanged(input, nil)
+}
+
+// validateStructChanged validates input as validateStruct does but reports
+// only the errors of the fields changed returns true for, by Go name, or of
+// the fields compared with them. A nil changed reports every field.
+func validateStructChanged(input interface{}, changed func(field string) bool) error {
+	err := validate.Struct(input)
+
+	errs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return err
+	}
+
+	res := &ValidationError{Fields: make([]FieldError, 0, len(errs))}
+	for _, fe := range errs {
+		if changed != nil && !changed(fe.StructField()) && !(strings.HasSuffix(fe.Tag(), "field") && changed(fe.Param())) {
+			continue
+		}
+
+		// the namespace starts with the struct name, nested fields keep their path
+		_, field, _ := strings.Cut(fe.Namespace(), ".")
+
+		res.Fields = append(res.Fields, FieldError{
+			Field:   field,
+			Message: fieldErrorMessage(reflect.TypeOf(input), fe),
+		})
+	}
+
+	if len(res.Fields) == 0 {
+		return nil
+	}
+
+	return res
+}
+
+func fieldErrorMessage(t reflect.Type, fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "min", "gte":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at least %s characters long", fe.Param())
+		}
+
+		return fmt.Sprintf("must be greater than or equal to %s", fe.Param())
+	case "max", "lte":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters long", fe.Param())
+		}
+
+		return fmt.Sprintf("must be less than or equal to %s", fe.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "email":
+		return "must be a valid email address"
+	case "gtefield":
+		name := fe.Param()
+		if f, ok := t.FieldByName(fe.Param()); ok {
+			name = jsonName(f)
+		}
+
+		return fmt.Sprintf("must not be less than %s", name)
+	case "notfuture":
+		return "must not be in the future"
+	case "iso639":
+		return "must be an ISO 639 language code"
+	}
+
+	return fmt.Sprintf("failed on the '%s' rule", fe.Tag())
+}
+
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return f.Name
+	}
+
+	return name
+}
@@ -3,12 +3,15 @@ package psql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type Actors struct {
@@ -49,19 +52,65 @@ func (a *Actors) Create(ctx context.Context, actor domain.ActorInput) (int64, er
 
 	var id int64
 
-	err := a.db.QueryRow(query, args...).Scan(&id)
+	err := conn(ctx, a.db).QueryRowContext(ctx, query, args...).Scan(&id)
 	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+			return 0, domain.ErrActorExists
+		}
+
 		return 0, err
 	}
 
 	return id, err
 }
 
+// GetByKey returns the actor with the given name, surname and birth year,
+// which identify an actor in imports.
+func (a *Actors) GetByKey(ctx context.Context, name, surname string, birthYear int) (domain.Actor, error) {
+	var actor domain.Actor
+	err := conn(ctx, a.db).QueryRowContext(ctx,
+		`SELECT id, name, surname, sex, birth_year, birth_place, rest_year, language, version FROM actors
+		WHERE name=$1 AND surname=$2 AND birth_year=$3 AND deleted_at IS NULL`, name, surname, birthYear).
+		Scan(&actor.ID, &actor.Name, &actor.Surname, &actor.Sex, &actor.BirthYear,
+			&actor.BirthPlace, &actor.RestYear, &actor.Language, &actor.Version)
+
+	if err == sql.ErrNoRows {
+		return actor, domain.ErrActorNotFound
+	}
+
+	return actor, err
+}
+
+// Upsert creates the actor or, if an actor with the same name, surname and
+// birth year exists, replaces the rest of its fields. The version is bumped
+// only if a field changed.
+func (a *Actors) Upsert(ctx context.Context, actor domain.ActorInput) (int64, error) {
+	var id int64
+
+	err := conn(ctx, a.db).QueryRowContext(ctx, `
+		INSERT INTO actors (name, surname, sex, birth_year, birth_place, rest_year, language)
+		values ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (name, surname, birth_year) WHERE deleted_at IS NULL DO UPDATE SET
+			sex=EXCLUDED.sex, birth_place=EXCLUDED.birth_place,
+			rest_year=EXCLUDED.rest_year, language=EXCLUDED.language,
+			version=actors.version + CASE
+				WHEN (actors.sex, actors.birth_place, actors.rest_year, actors.language) IS DISTINCT FROM
+					(EXCLUDED.sex, EXCLUDED.birth_place, EXCLUDED.rest_year, EXCLUDED.language)
+				THEN 1 ELSE 0 END
+		RETURNING id`,
+		actor.Name, actor.Surname, actor.Sex, actor.BirthYear, actor.BirthPlace, actor.RestYear, actor.Language).
+		Scan(&id)
+
+	return id, err
+}
+
 func (a *Actors) GetByID(ctx context.Context, id int64) (domain.Actor, error) {
 	var actor domain.Actor
-	err := a.db.QueryRow("SELECT id, name, surname, sex, birth_year, birth_place, rest_year, language FROM actors WHERE id=$1", id).
+	err := conn(ctx, a.db).QueryRowContext(ctx,
+		"SELECT id, name, surname, sex, birth_year, birth_place, rest_year, language, version FROM actors WHERE id=$1 AND deleted_at IS NULL", id).
 		Scan(&actor.ID, &actor.Name, &actor.Surname, &actor.Sex, &actor.BirthYear,
-			&actor.BirthPlace, &actor.RestYear, &actor.Language)
+			&actor.BirthPlace, &actor.RestYear, &actor.Language, &actor.Version)
 
 	if err == sql.ErrNoRows {
 		return actor, domain.ErrActorNotFound
@@ -70,31 +119,144 @@ func (a *Actors) GetByID(ctx context.Context, id int64) (domain.Actor, error) {
 	return actor, err
 }
 
-func (a *Actors) GetAllActors(ctx context.Context) ([]domain.Actor, error) {
-	rows, err := a.db.Query("SELECT * FROM actors")
-	if err == nil {
-		defer rows.Close()
+func (a *Actors) GetAllActors(ctx context.Context, opts domain.ActorsListOptions) (domain.ActorsPage, error) {
+	sortColumn, ok := actorsSortColumns[opts.Sort]
+	if !ok {
+		sortColumn = "id"
+	}
+
+	order := "ASC"
+	cmp := ">"
+
+	if opts.Order == "desc" {
+		order = "DESC"
+		cmp = "<"
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = domain.DefaultActorsLimit
+	}
+
+	if limit > domain.MaxActorsLimit {
+		limit = domain.MaxActorsLimit
+	}
+
+	conds := []string{"deleted_at IS NULL"}
+	args := make([]interface{}, 0)
+	argId := 1
+
+	if opts.Sex != nil {
+		conds = append(conds, fmt.Sprintf("sex=$%d", argId))
+		args = append(args, *opts.Sex)
+		argId++
+	}
+
+	if opts.BirthYearFrom != nil {
+		conds = append(conds, fmt.Sprintf("birth_year>=$%d", argId))
+		args = append(args, *opts.BirthYearFrom)
+		argId++
+	}
+
+	if opts.BirthYearTo != nil {
+		conds = append(conds, fmt.Sprintf("birth_year<=$%d", argId))
+		args = append(args, *opts.BirthYearTo)
+		argId++
+	}
+
+	if opts.BirthPlace != nil {
+		conds = append(conds, fmt.Sprintf("birth_place=$%d", argId))
+		args = append(args, *opts.BirthPlace)
+		argId++
+	}
+
+	if opts.Language != nil {
+		conds = append(conds, fmt.Sprintf("language=$%d", argId))
+		args = append(args, *opts.Language)
+		argId++
 	}
 
+	if opts.Retired != nil {
+		if *opts.Retired {
+			conds = append(conds, "rest_year IS NOT NULL")
+		} else {
+			conds = append(conds, "rest_year IS NULL")
+		}
+	}
+
+	if opts.Cursor != "" {
+		cur, err := decodeActorsCursor(opts.Cursor, sortColumn, order)
+		if err != nil {
+			return domain.ActorsPage{}, err
+		}
+
+		if sortColumn == "id" {
+			conds = append(conds, fmt.Sprintf("id %s $%d", cmp, argId))
+			args = append(args, cur.ID)
+			argId++
+		} else {
+			conds = append(conds, fmt.Sprintf("(%s, id) %s ($%d, $%d)", sortColumn, cmp, argId, argId+1))
+			args = append(args, cur.Value, cur.ID)
+			argId += 2
+		}
+	}
+
+	query := "SELECT id, name, surname, sex, birth_year, birth_place, rest_year, language FROM actors WHERE " +
+		strings.Join(conds, " AND ")
+
+	if sortColumn == "id" {
+		query += fmt.Sprintf(" ORDER BY id %s", order)
+	} else {
+		query += fmt.Sprintf(" ORDER BY %s %s, id %s", sortColumn, order, order)
+	}
+
+	query += fmt.Sprintf(" LIMIT $%d", argId)
+	args = append(args, limit+1)
+
+	rows, err := a.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return domain.ActorsPage{}, err
 	}
+	defer rows.Close()
 
-	actors := make([]domain.Actor, 0)
+	actors := make([]domain.Actor, 0, limit+1)
 
 	for rows.Next() {
 		var actor domain.Actor
 		if err := rows.Scan(&actor.ID, &actor.Name, &actor.Surname, &actor.Sex, &actor.BirthYear, &actor.BirthPlace, &actor.RestYear, &actor.Language); err != nil {
-			return nil, err
+			return domain.ActorsPage{}, err
 		}
 
 		actors = append(actors, actor)
 	}
 
-	return actors, rows.Err()
+	if err := rows.Err(); err != nil {
+		return domain.ActorsPage{}, err
+	}
+
+	page := domain.ActorsPage{Actors: actors}
+
+	if len(actors) > limit {
+		page.Actors = actors[:limit]
+		last := page.Actors[limit-1]
+
+		page.NextCursor, err = encodeActorsCursor(actorsCursor{
+			Sort:  sortColumn,
+			Order: order,
+			Value: actorSortValue(last, sortColumn),
+			ID:    last.ID,
+		})
+		if err != nil {
+			return domain.ActorsPage{}, err
+		}
+	}
+
+	return page, nil
 }
 
-func (a *Actors) Update(ctx context.Context, id int64, inp domain.UpdateActorInfo) error {
+// Update changes the fields of inp that aren't nil. Unless version is
+// domain.AnyVersion the actor is only updated if it still has this version.
+func (a *Actors) Update(ctx context.Context, id, version int64, inp domain.UpdateActorInfo) error {
 	setValues := make([]string, 0)
 	args := make([]interface{}, 0)
 	argId := 1
@@ -129,25 +291,428 @@ func (a *Actors) Update(ctx context.Context, id int64, inp domain.UpdateActorInf
 		argId++
 	}
 
+	// nothing to change, the update still checks the actor and its version
+	if len(setValues) == 0 {
+		setValues = append(setValues, "version=version")
+	} else {
+		setValues = append(setValues, "version=version+1")
+	}
+
 	setQuery := strings.Join(setValues, ", ")
 
-	query := fmt.Sprintf("UPDATE actors SET %s WHERE id=$%d", setQuery, argId)
+	query := fmt.Sprintf("UPDATE actors SET %s WHERE id=$%d AND deleted_at IS NULL AND ($%d=0 OR version=$%d)",
+		setQuery, argId, argId+1, argId+1)
+
+	args = append(args, id, version)
+
+	res, err := conn(ctx, a.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+			return domain.ErrActorExists
+		}
+
+		return err
+	}
+
+	return a.checkUpdated(ctx, res, id)
+}
+
+// Replace sets every column of the actor, nil rest_year and language are
+// stored as NULL. The version is checked as in Update.
+func (a *Actors) Replace(ctx context.Context, id, version int64, actor domain.ActorInput) error {
+	res, err := conn(ctx, a.db).ExecContext(ctx, `
+		UPDATE actors
+		SET name=$1, surname=$2, sex=$3, birth_year=$4, birth_place=$5, rest_year=$6, language=$7,
+			version=version+1
+		WHERE id=$8 AND deleted_at IS NULL AND ($9=0 OR version=$9)`,
+		actor.Name, actor.Surname, actor.Sex, actor.BirthYear, actor.BirthPlace, actor.RestYear, actor.Language,
+		id, version)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+			return domain.ErrActorExists
+		}
+
+		return err
+	}
+
+	return a.checkUpdated(ctx, res, id)
+}
+
+// Delete moves the actor to the trash, the version is checked as in Update.
+func (a *Actors) Delete(ctx context.Context, id, version int64) error {
+	res, err := conn(ctx, a.db).ExecContext(ctx,
+		`UPDATE actors SET deleted_at=now(), version=version+1
+		WHERE id=$1 AND deleted_at IS NULL AND ($2=0 OR version=$2)`, id, version)
+	if err != nil {
+		return err
+	}
+
+	return a.checkUpdated(ctx, res, id)
+}
+
+// GetDeleted returns the actors in the trash, the most recently deleted first.
+func (a *Actors) GetDeleted(ctx context.Context) ([]domain.DeletedActor, error) {
+	rows, err := conn(ctx, a.db).QueryContext(ctx, `
+		SELECT id, name, surname, sex, birth_year, birth_place, rest_year, language, version, deleted_at
+		FROM actors
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	actors := make([]domain.DeletedActor, 0)
 
-	args = append(args, id)
-	_, err := a.db.Exec(query, args...)
+	for rows.Next() {
+		var actor domain.DeletedActor
+		if err := rows.Scan(&actor.ID, &actor.Name, &actor.Surname, &actor.Sex, &actor.BirthYear,
+			&actor.BirthPlace, &actor.RestYear, &actor.Language, &actor.Version, &actor.DeletedAt); err != nil {
+			return nil, err
+		}
+
+		actors = append(actors, actor)
+	}
+
+	return actors, rows.Err()
+}
+
+// GetDeletedByID returns the actor with the id if it's in the trash.
+func (a *Actors) GetDeletedByID(ctx context.Context, id int64) (domain.DeletedActor, error) {
+	var actor domain.DeletedActor
+	err := conn(ctx, a.db).QueryRowContext(ctx, `
+		SELECT id, name, surname, sex, birth_year, birth_place, rest_year, language, version, deleted_at
+		FROM actors WHERE id=$1 AND deleted_at IS NOT NULL`, id).
+		Scan(&actor.ID, &actor.Name, &actor.Surname, &actor.Sex, &actor.BirthYear,
+			&actor.BirthPlace, &actor.RestYear, &actor.Language, &actor.Version, &actor.DeletedAt)
 
 	if err == sql.ErrNoRows {
-		return domain.ErrActorNotFound
+		return actor, domain.ErrActorNotFound
+	}
+
+	return actor, err
+}
+
+// Restore takes the actor out of the trash. It fails with
+// domain.ErrActorExists if an actor with the same name, surname and birth
+// year has been created since.
+func (a *Actors) Restore(ctx context.Context, id int64) error {
+	res, err := conn(ctx, a.db).ExecContext(ctx,
+		"UPDATE actors SET deleted_at=NULL, version=version+1 WHERE id=$1 AND deleted_at IS NOT NULL", id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+			return domain.ErrActorExists
+		}
+
+		return err
 	}
 
+	return checkAffected(res, domain.ErrActorNotFound)
+}
+
+// Purge permanently deletes the actor if it's in the trash, with its roles
+// and followers.
+func (a *Actors) Purge(ctx context.Context, id int64) error {
+	res, err := conn(ctx, a.db).ExecContext(ctx,
+		"DELETE FROM actors WHERE id=$1 AND deleted_at IS NOT NULL", id)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(res, domain.ErrActorNotFound)
+}
+
+// PurgeDeleted permanently deletes at most limit actors that have been in the
+// trash for longer than retention and returns them.
+func (a *Actors) PurgeDeleted(ctx context.Context, retention time.Duration, limit int) ([]domain.DeletedActor, error) {
+	rows, err := conn(ctx, a.db).QueryContext(ctx, `
+		DELETE FROM actors
+		WHERE id IN (
+			SELECT id FROM actors
+			WHERE deleted_at < now() - make_interval(secs => $1)
+			ORDER BY deleted_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED)
+		RETURNING id, name, surname, sex, birth_year, birth_place, rest_year, language, version, deleted_at`,
+		retention.Seconds(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	purged := make([]domain.DeletedActor, 0, limit)
+
+	for rows.Next() {
+		var actor domain.DeletedActor
+		if err := rows.Scan(&actor.ID, &actor.Name, &actor.Surname, &actor.Sex, &actor.BirthYear,
+			&actor.BirthPlace, &actor.RestYear, &actor.Language, &actor.Version, &actor.DeletedAt); err != nil {
+			return nil, err
+		}
+
+		purged = append(purged, actor)
+	}
+
+	return purged, rows.Err()
+}
+
+// checkUpdated tells why a versioned write of the actor matched no row: it
+// doesn't exist or has another version.
+func (a *Actors) checkUpdated(ctx context.Context, res sql.Result, id int64) error {
+	err := checkAffected(res, domain.ErrActorNotFound)
+	if !errors.Is(err, domain.ErrActorNotFound) {
+		return err
+	}
+
+	exists, err := a.exists(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return domain.ErrActorModified
+	}
+
+	return domain.ErrActorNotFound
+}
+
+// exists tells if the actor exists and isn't in the trash.
+func (a *Actors) exists(ctx context.Context, id int64) (bool, error) {
+	var exists bool
+
+	err := conn(ctx, a.db).QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM actors WHERE id=$1 AND deleted_at IS NULL)", id).Scan(&exists)
+
+	return exists, err
+}
+
+// CreateBatch inserts the actors with COPY in one transaction and returns them
+// with their ids. If an actor already exists none are inserted.
+func (a *Actors) CreateBatch(ctx context.Context, actors []domain.ActorInput) ([]domain.Actor, error) {
+	created := make([]domain.Actor, 0, len(actors))
+
+	err := withinTx(ctx, a.db, func(ctx context.Context, q querier) error {
+		if _, err := q.ExecContext(ctx, `
+			CREATE TEMP TABLE actors_import (
+				ord         integer,
+				name        varchar(20),
+				surname     varchar(20),
+				sex         varchar(6),
+				birth_year  integer,
+				birth_place varchar(15),
+				rest_year   integer,
+				language    varchar(15)
+			) ON COMMIT DROP`); err != nil {
+			return err
+		}
+
+		stmt, err := q.PrepareContext(ctx, pq.CopyIn("actors_import",
+			"ord", "name", "surname", "sex", "birth_year", "birth_place", "rest_year", "language"))
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for i, actor := range actors {
+			if _, err := stmt.ExecContext(ctx, i, actor.Name, actor.Surname, actor.Sex, actor.BirthYear,
+				actor.BirthPlace, actor.RestYear, actor.Language); err != nil {
+				return err
+			}
+		}
+
+		if _, err := stmt.ExecContext(ctx); err != nil {
+			return err
+		}
+
+		rows, err := q.QueryContext(ctx, `
+			INSERT INTO actors (name, surname, sex, birth_year, birth_place, rest_year, language)
+			SELECT name, surname, sex, birth_year, birth_place, rest_year, language
+			FROM actors_import ORDER BY ord
+			RETURNING id, name, surname, sex, birth_year, birth_place, rest_year, language, version`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var actor domain.Actor
+			if err := rows.Scan(&actor.ID, &actor.Name, &actor.Surname, &actor.Sex, &actor.BirthYear,
+				&actor.BirthPlace, &actor.RestYear, &actor.Language, &actor.Version); err != nil {
+				return err
+			}
+
+			created = append(created, actor)
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+			return nil, domain.ErrActorExists
+		}
+
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// Export passes every actor ordered by id to fn. Rows are read as they come,
+// so the catalogue is never held in memory at once.
+func (a *Actors) Export(ctx context.Context, fn func(actor domain.Actor) error) error {
+	rows, err := a.db.QueryContext(ctx,
+		`SELECT id, name, surname, sex, birth_year, birth_place, rest_year, language FROM actors
+		WHERE deleted_at IS NULL ORDER BY id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var actor domain.Actor
+		if err := rows.Scan(&actor.ID, &actor.Name, &actor.Surname, &actor.Sex, &actor.BirthYear,
+			&actor.BirthPlace, &actor.RestYear, &actor.Language); err != nil {
+			return err
+		}
+
+		if err := fn(actor); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// Search ranks actors by full-text match over name, surname and birth place
+// and by trigram similarity of the same text, so that misspelled queries
+// ("Di Caprio" for "DiCaprio") still find the actor.
+func (a *Actors) Search(ctx context.Context, input domain.ActorsSearchInput) ([]domain.ActorSearchResult, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = domain.DefaultActorsLimit
+	}
+
+	rows, err := a.db.QueryContext(ctx, `
+		SELECT id, name, surname, sex, birth_year, birth_place, rest_year, language,
+			ts_rank(search_vector, plainto_tsquery('simple', $1)) +
+			word_similarity($1, name || ' ' || surname || ' ' || birth_place) AS rank
+		FROM actors
+		WHERE (search_vector @@ plainto_tsquery('simple', $1)
+			OR $1 <% (name || ' ' || surname || ' ' || birth_place))
+			AND deleted_at IS NULL
+		ORDER BY rank DESC, id
+		LIMIT $2`, input.Query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]domain.ActorSearchResult, 0)
+
+	for rows.Next() {
+		var res domain.ActorSearchResult
+		if err := rows.Scan(&res.ID, &res.Name, &res.Surname, &res.Sex, &res.BirthYear,
+			&res.BirthPlace, &res.RestYear, &res.Language, &res.Rank); err != nil {
+			return nil, err
+		}
+
+		results = append(results, res)
+	}
+
+	return results, rows.Err()
+}
+
+// AddRevision stores the revision, created at the current time.
+func (a *Actors) AddRevision(ctx context.Context, rev domain.ActorRevision) error {
+	actor, err := json.Marshal(rev.Actor)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn(ctx, a.db).ExecContext(ctx,
+		`INSERT INTO actor_revisions (actor_id, revision, action, actor, user_id)
+		values ($1, $2, $3, $4, $5)`,
+		rev.ActorID, rev.Revision, rev.Action, actor, rev.UserID)
+
 	return err
 }
 
-func (a *Actors) Delete(ctx context.Context, id int64) error {
-	_, err := a.db.Exec("DELETE FROM actors WHERE id=$1", id)
+// GetRevisions returns the revisions of the actor, the latest first. The
+// history of actors in the trash isn't shown.
+func (a *Actors) GetRevisions(ctx context.Context, actorId int64) ([]domain.ActorRevision, error) {
+	exists, err := a.exists(ctx, actorId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, domain.ErrActorNotFound
+	}
+
+	rows, err := conn(ctx, a.db).QueryContext(ctx, `
+		SELECT actor_id, revision, action, actor, user_id, created_at
+		FROM actor_revisions
+		WHERE actor_id=$1
+		ORDER BY revision DESC`, actorId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := make([]domain.ActorRevision, 0)
+
+	for rows.Next() {
+		rev, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, rev)
+	}
+
+	return revisions, rows.Err()
+}
+
+// GetRevision returns the revision of the actor, as GetRevisions it fails for
+// actors in the trash.
+func (a *Actors) GetRevision(ctx context.Context, actorId, revision int64) (domain.ActorRevision, error) {
+	exists, err := a.exists(ctx, actorId)
+	if err != nil {
+		return domain.ActorRevision{}, err
+	}
+
+	if !exists {
+		return domain.ActorRevision{}, domain.ErrActorNotFound
+	}
+
+	rev, err := scanRevision(conn(ctx, a.db).QueryRowContext(ctx, `
+		SELECT actor_id, revision, action, actor, user_id, created_at
+		FROM actor_revisions
+		WHERE actor_id=$1 AND revision=$2`, actorId, revision))
 	if err == sql.ErrNoRows {
-		return domain.ErrActorNotFound
+		return rev, domain.ErrRevisionNotFound
 	}
 
-	return err
+	return rev, err
+}
+
+// rowScanner is a *sql.Row or *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRevision(row rowScanner) (domain.ActorRevision, error) {
+	var (
+		rev   domain.ActorRevision
+		actor []byte
+	)
+
+	if err := row.Scan(&rev.ActorID, &rev.Revision, &rev.Action, &actor, &rev.UserID, &rev.CreatedAt); err != nil {
+		return rev, err
+	}
+
+	return rev, json.Unmarshal(actor, &rev.Actor)
 }
@@ -0,0 +1,78 @@
+package psql
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+)
+
+var actorsSortColumns = map[string]string{
+	"":           "id",
+	"id":         "id",
+	"name":       "name",
+	"surname":    "surname",
+	"birth_year": "birth_year",
+}
+
+// actorsCursor points at the last row of a page: the value of the sort
+// column and the id used as a tie-breaker. It also keeps the sort column and
+// the order of the listing it was issued for, so it can't be reused with
+// another one.
+type actorsCursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Value string `json:"v"`
+	ID    int64  `json:"id"`
+}
+
+func encodeActorsCursor(cur actorsCursor) (string, error) {
+	b, err := json.Marshal(cur)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decodeActorsCursor decodes the cursor of the listing sorted by column in
+// order. A cursor issued for another listing or tampered with fails with
+// domain.ErrInvalidCursor.
+func decodeActorsCursor(s, column, order string) (actorsCursor, error) {
+	var cur actorsCursor
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cur, domain.ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(b, &cur); err != nil {
+		return cur, domain.ErrInvalidCursor
+	}
+
+	if cur.Sort != column || cur.Order != order || cur.ID <= 0 {
+		return cur, domain.ErrInvalidCursor
+	}
+
+	if column == "birth_year" {
+		if _, err := strconv.Atoi(cur.Value); err != nil {
+			return cur, domain.ErrInvalidCursor
+		}
+	}
+
+	return cur, nil
+}
+
+func actorSortValue(actor domain.Actor, column string) string {
+	switch column {
+	case "name":
+		return actor.Name
+	case "surname":
+		return actor.Surname
+	case "birth_year":
+		return strconv.Itoa(actor.BirthYear)
+	default:
+		return strconv.FormatInt(actor.ID, 10)
+	}
+}
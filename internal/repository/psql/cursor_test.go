@@ -0,0 +1,70 @@
+package psql
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+)
+
+func TestActorsCursorRoundTrip(t *testing.T) {
+	for _, cur := range []actorsCursor{
+		{Sort: "id", Order: "ASC", Value: "42", ID: 42},
+		{Sort: "name", Order: "ASC", Value: "Keanu", ID: 42},
+		{Sort: "surname", Order: "DESC", Value: "Léa Seydoux, \"jr\"", ID: 3},
+		{Sort: "birth_year", Order: "DESC", Value: "1964", ID: 7},
+	} {
+		s, err := encodeActorsCursor(cur)
+		if err != nil {
+			t.Fatalf("encodeActorsCursor(%+v): %v", cur, err)
+		}
+
+		got, err := decodeActorsCursor(s, cur.Sort, cur.Order)
+		if err != nil {
+			t.Fatalf("decodeActorsCursor(%q): %v", s, err)
+		}
+
+		if got != cur {
+			t.Errorf("decodeActorsCursor(%q) = %+v, want %+v", s, got, cur)
+		}
+	}
+}
+
+func TestActorsCursorOfAnotherListing(t *testing.T) {
+	s, err := encodeActorsCursor(actorsCursor{Sort: "name", Order: "ASC", Value: "Keanu", ID: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := decodeActorsCursor(s, "surname", "ASC"); !errors.Is(err, domain.ErrInvalidCursor) {
+		t.Errorf("other sort column: %v, want %v", err, domain.ErrInvalidCursor)
+	}
+
+	if _, err := decodeActorsCursor(s, "name", "DESC"); !errors.Is(err, domain.ErrInvalidCursor) {
+		t.Errorf("other order: %v, want %v", err, domain.ErrInvalidCursor)
+	}
+}
+
+func TestActorsCursorTampered(t *testing.T) {
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+
+	for _, s := range []string{
+		"not a cursor!",
+		encode("42"),
+		encode(`{"s":"id","o":"ASC","v":"1","id":"1"}`),
+		encode(`{"s":"id","o":"ASC","v":"1"}`),
+		encode(`{"s":"id","o":"ASC","v":"1","id":-1}`),
+	} {
+		if _, err := decodeActorsCursor(s, "id", "ASC"); !errors.Is(err, domain.ErrInvalidCursor) {
+			t.Errorf("decodeActorsCursor(%q): %v, want %v", s, err, domain.ErrInvalidCursor)
+		}
+	}
+
+	s := encode(`{"s":"birth_year","o":"ASC","v":"1964; --","id":7}`)
+	if _, err := decodeActorsCursor(s, "birth_year", "ASC"); !errors.Is(err, domain.ErrInvalidCursor) {
+		t.Errorf("non numeric birth_year: %v, want %v", err, domain.ErrInvalidCursor)
+	}
+}
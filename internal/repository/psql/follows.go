@@ -0,0 +1,116 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	"github.com/lib/pq"
+)
+
+type Follows struct {
+	db *sql.DB
+}
+
+func NewFollows(db *sql.DB) *Follows {
+	return &Follows{
+		db: db,
+	}
+}
+
+// Follow is idempotent: following an already followed actor is not an error,
+// it returns false as nothing was added. Actors in the trash can't be
+// followed.
+func (f *Follows) Follow(ctx context.Context, userId, actorId int64) (bool, error) {
+	res, err := conn(ctx, f.db).ExecContext(ctx,
+		`INSERT INTO follows (following_user_id, followed_actor_id)
+		SELECT $1, id FROM actors WHERE id=$2 AND deleted_at IS NULL
+		ON CONFLICT (following_user_id, followed_actor_id) DO NOTHING`,
+		userId, actorId)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pqForeignKeyViolation {
+			return false, domain.ErrActorNotFound
+		}
+
+		return false, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil || n > 0 {
+		return err == nil, err
+	}
+
+	// nothing inserted: already followed or no such actor
+	var exists bool
+	if err := conn(ctx, f.db).QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM actors WHERE id=$1 AND deleted_at IS NULL)", actorId).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	if !exists {
+		return false, domain.ErrActorNotFound
+	}
+
+	return false, nil
+}
+
+// Unfollow returns false if the user didn't follow the actor.
+func (f *Follows) Unfollow(ctx context.Context, userId, actorId int64) (bool, error) {
+	res, err := conn(ctx, f.db).ExecContext(ctx,
+		"DELETE FROM follows WHERE following_user_id=$1 AND followed_actor_id=$2", userId, actorId)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+
+	return n > 0, err
+}
+
+func (f *Follows) GetFollows(ctx context.Context, userId int64) ([]domain.FollowedActor, error) {
+	rows, err := conn(ctx, f.db).QueryContext(ctx, `
+		SELECT a.id, a.name, a.surname, a.sex, a.birth_year, a.birth_place, a.rest_year, a.language, f.created_at
+		FROM follows f
+		JOIN actors a ON a.id = f.followed_actor_id
+		WHERE f.following_user_id=$1 AND a.deleted_at IS NULL
+		ORDER BY f.created_at DESC, a.id`, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	follows := make([]domain.FollowedActor, 0)
+
+	for rows.Next() {
+		var fa domain.FollowedActor
+		if err := rows.Scan(&fa.ID, &fa.Name, &fa.Surname, &fa.Sex, &fa.BirthYear,
+			&fa.BirthPlace, &fa.RestYear, &fa.Language, &fa.FollowedAt); err != nil {
+			return nil, err
+		}
+
+		follows = append(follows, fa)
+	}
+
+	return follows, rows.Err()
+}
+
+func (f *Follows) CountFollowers(ctx context.Context, actorId int64) (int64, error) {
+	var exists bool
+
+	var count int64
+
+	err := conn(ctx, f.db).QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM actors WHERE id=$1 AND deleted_at IS NULL),
+			(SELECT count(*) FROM follows WHERE followed_actor_id=$1)`, actorId).
+		Scan(&exists, &count)
+	if err != nil {
+		return 0, err
+	}
+
+	if !exists {
+		return 0, domain.ErrActorNotFound
+	}
+
+	return count, nil
+}
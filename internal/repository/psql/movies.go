@@ -0,0 +1,267 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	"github.com/lib/pq"
+)
+
+const (
+	pqForeignKeyViolation = "23503"
+	pqUniqueViolation     = "23505"
+)
+
+type Movies struct {
+	db *sql.DB
+}
+
+func NewMovies(db *sql.DB) *Movies {
+	return &Movies{
+		db: db,
+	}
+}
+
+func (m *Movies) Create(ctx context.Context, movie domain.MovieInput) (int64, error) {
+	genres := movie.Genres
+	if genres == nil {
+		genres = []string{}
+	}
+
+	var id int64
+
+	err := m.db.QueryRowContext(ctx,
+		"INSERT INTO movies (title, release_year, genres, runtime, studio) values ($1, $2, $3, $4, $5) RETURNING ID",
+		movie.Title, movie.ReleaseYear, pq.Array(genres), movie.Runtime, movie.Studio).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (m *Movies) GetByID(ctx context.Context, id int64) (domain.Movie, error) {
+	var movie domain.Movie
+	err := m.db.QueryRowContext(ctx,
+		"SELECT id, title, release_year, genres, runtime, studio FROM movies WHERE id=$1", id).
+		Scan(&movie.ID, &movie.Title, &movie.ReleaseYear, pq.Array(&movie.Genres), &movie.Runtime, &movie.Studio)
+
+	if err == sql.ErrNoRows {
+		return movie, domain.ErrMovieNotFound
+	}
+
+	return movie, err
+}
+
+func (m *Movies) GetAll(ctx context.Context) ([]domain.Movie, error) {
+	rows, err := m.db.QueryContext(ctx,
+		"SELECT id, title, release_year, genres, runtime, studio FROM movies ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movies := make([]domain.Movie, 0)
+
+	for rows.Next() {
+		var movie domain.Movie
+		if err := rows.Scan(&movie.ID, &movie.Title, &movie.ReleaseYear, pq.Array(&movie.Genres),
+			&movie.Runtime, &movie.Studio); err != nil {
+			return nil, err
+		}
+
+		movies = append(movies, movie)
+	}
+
+	return movies, rows.Err()
+}
+
+func (m *Movies) Update(ctx context.Context, id int64, inp domain.UpdateMovieInfo) error {
+	setValues := make([]string, 0)
+	args := make([]interface{}, 0)
+	argId := 1
+
+	if inp.Title != nil {
+		setValues = append(setValues, fmt.Sprintf("title=$%d", argId))
+		args = append(args, *inp.Title)
+		argId++
+	}
+
+	if inp.ReleaseYear != nil {
+		setValues = append(setValues, fmt.Sprintf("release_year=$%d", argId))
+		args = append(args, *inp.ReleaseYear)
+		argId++
+	}
+
+	if inp.Genres != nil {
+		setValues = append(setValues, fmt.Sprintf("genres=$%d", argId))
+		args = append(args, pq.Array(*inp.Genres))
+		argId++
+	}
+
+	if inp.Runtime != nil {
+		setValues = append(setValues, fmt.Sprintf("runtime=$%d", argId))
+		args = append(args, *inp.Runtime)
+		argId++
+	}
+
+	if inp.Studio != nil {
+		setValues = append(setValues, fmt.Sprintf("studio=$%d", argId))
+		args = append(args, *inp.Studio)
+		argId++
+	}
+
+	if len(setValues) == 0 {
+		_, err := m.GetByID(ctx, id)
+
+		return err
+	}
+
+	setQuery := strings.Join(setValues, ", ")
+	query := fmt.Sprintf("UPDATE movies SET %s WHERE id=$%d", setQuery, argId)
+
+	args = append(args, id)
+
+	res, err := m.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(res, domain.ErrMovieNotFound)
+}
+
+func (m *Movies) Delete(ctx context.Context, id int64) error {
+	res, err := m.db.ExecContext(ctx, "DELETE FROM movies WHERE id=$1", id)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(res, domain.ErrMovieNotFound)
+}
+
+// AddRole casts the actor in the movie. Actors in the trash can't be cast,
+// the foreign key alone would accept them.
+func (m *Movies) AddRole(ctx context.Context, movieId int64, role domain.RoleInput) (int64, error) {
+	var id int64
+
+	err := m.db.QueryRowContext(ctx,
+		`INSERT INTO actor_roles (actor_id, movie_id, character_name, billing_order, role_type)
+		SELECT id, $2, $3, $4, $5 FROM actors WHERE id=$1 AND deleted_at IS NULL
+		RETURNING id`,
+		role.ActorID, movieId, role.CharacterName, role.BillingOrder, role.RoleType).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, domain.ErrActorNotFound
+		}
+
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch {
+			case pqErr.Code == pqForeignKeyViolation && pqErr.Constraint == "actor_roles_actor_id_fkey":
+				return 0, domain.ErrActorNotFound
+			case pqErr.Code == pqForeignKeyViolation:
+				return 0, domain.ErrMovieNotFound
+			case pqErr.Code == pqUniqueViolation:
+				return 0, domain.ErrRoleExists
+			}
+		}
+
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (m *Movies) DeleteRole(ctx context.Context, movieId, roleId int64) error {
+	res, err := m.db.ExecContext(ctx, "DELETE FROM actor_roles WHERE id=$1 AND movie_id=$2", roleId, movieId)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(res, domain.ErrRoleNotFound)
+}
+
+func (m *Movies) GetCast(ctx context.Context, movieId int64) ([]domain.CastMember, error) {
+	if _, err := m.GetByID(ctx, movieId); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.QueryContext(ctx, `
+		SELECT r.id, r.actor_id, r.movie_id, r.character_name, r.billing_order, r.role_type, a.name, a.surname
+		FROM actor_roles r
+		JOIN actors a ON a.id = r.actor_id
+		WHERE r.movie_id=$1 AND a.deleted_at IS NULL
+		ORDER BY r.billing_order NULLS LAST, r.id`, movieId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cast := make([]domain.CastMember, 0)
+
+	for rows.Next() {
+		var member domain.CastMember
+		if err := rows.Scan(&member.ID, &member.ActorID, &member.MovieID, &member.CharacterName,
+			&member.BillingOrder, &member.RoleType, &member.Name, &member.Surname); err != nil {
+			return nil, err
+		}
+
+		cast = append(cast, member)
+	}
+
+	return cast, rows.Err()
+}
+
+func (m *Movies) GetFilmography(ctx context.Context, actorId int64) ([]domain.FilmographyItem, error) {
+	var exists bool
+	if err := m.db.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM actors WHERE id=$1 AND deleted_at IS NULL)", actorId).Scan(&exists); err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, domain.ErrActorNotFound
+	}
+
+	rows, err := m.db.QueryContext(ctx, `
+		SELECT r.id, r.actor_id, r.movie_id, r.character_name, r.billing_order, r.role_type, m.title, m.release_year
+		FROM actor_roles r
+		JOIN movies m ON m.id = r.movie_id
+		WHERE r.actor_id=$1
+		ORDER BY m.release_year DESC, m.id`, actorId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	filmography := make([]domain.FilmographyItem, 0)
+
+	for rows.Next() {
+		var item domain.FilmographyItem
+		if err := rows.Scan(&item.ID, &item.ActorID, &item.MovieID, &item.CharacterName,
+			&item.BillingOrder, &item.RoleType, &item.Title, &item.ReleaseYear); err != nil {
+			return nil, err
+		}
+
+		filmography = append(filmography, item)
+	}
+
+	return filmography, rows.Err()
+}
+
+func checkAffected(res sql.Result, notFound error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return notFound
+	}
+
+	return nil
+}
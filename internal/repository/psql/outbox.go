@@ -0,0 +1,109 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+)
+
+type Outbox struct {
+	db *sql.DB
+}
+
+func NewOutbox(db *sql.DB) *Outbox {
+	return &Outbox{
+		db: db,
+	}
+}
+
+// Add stores a message to be published. Called with a context of
+// Transactor.WithinTx, the message is stored only if the transaction commits.
+func (o *Outbox) Add(ctx context.Context, msg domain.OutboxMessage) error {
+	_, err := conn(ctx, o.db).ExecContext(ctx,
+		"INSERT INTO outbox (routing_key, subject, payload) values ($1, $2, $3)", msg.RoutingKey, msg.Subject, msg.Payload)
+
+	return err
+}
+
+// outboxClaimTimeout is how long claimed messages are hidden from other
+// relays. A message of a relay that died while publishing is retried after it.
+const outboxClaimTimeout = 5 * time.Minute
+
+// Process claims up to limit messages due for publishing and passes them to
+// publish one by one. Published messages are marked as sent, failed ones are
+// retried later with an exponential backoff. Claiming only moves the next
+// attempt past outboxClaimTimeout, so no lock or transaction is held while
+// publishing and messages claimed by another relay are skipped.
+func (o *Outbox) Process(ctx context.Context, limit int,
+	publish func(ctx context.Context, msg domain.OutboxMessage) error,
+) (int, error) {
+	msgs, err := o.claim(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+
+	for _, msg := range msgs {
+		if err := publish(ctx, msg); err != nil {
+			if _, err := o.db.ExecContext(ctx, `
+				UPDATE outbox SET attempts=attempts+1, last_error=$1,
+					next_attempt_at=now() + make_interval(secs => least(300, power(2, attempts)))
+				WHERE id=$2`, err.Error(), msg.ID); err != nil {
+				return sent, err
+			}
+
+			continue
+		}
+
+		if _, err := o.db.ExecContext(ctx, "UPDATE outbox SET sent_at=now() WHERE id=$1", msg.ID); err != nil {
+			return sent, err
+		}
+
+		sent++
+	}
+
+	return sent, nil
+}
+
+func (o *Outbox) claim(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+	rows, err := o.db.QueryContext(ctx, `
+		UPDATE outbox SET next_attempt_at=now() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE sent_at IS NULL AND next_attempt_at <= now()
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED)
+		RETURNING id, routing_key, subject, payload, attempts, created_at`,
+		limit, outboxClaimTimeout.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	msgs := make([]domain.OutboxMessage, 0, limit)
+
+	for rows.Next() {
+		var msg domain.OutboxMessage
+		if err := rows.Scan(&msg.ID, &msg.RoutingKey, &msg.Subject, &msg.Payload, &msg.Attempts, &msg.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		msgs = append(msgs, msg)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// RETURNING doesn't keep the order of the subquery
+	sort.Slice(msgs, func(i, j int) bool {
+		return msgs[i].ID < msgs[j].ID
+	})
+
+	return msgs, nil
+}
@@ -3,6 +3,7 @@ package psql
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
 	_ "github.com/lib/pq"
@@ -18,23 +19,173 @@ func NewTokens(db *sql.DB) *Tokens {
 	}
 }
 
-func (t *Tokens) CreateToken(ctx context.Context, rt domain.RefreshToken) error {
-	_, err := t.db.Exec(
-		"INSERT INTO refresh_tokens (user_id, token, expires_at) values ($1, $2, $3)",
-		rt.UserId, rt.Token, rt.ExpiresAt)
+// CreateSession stores a new session together with the first refresh token
+// of its family.
+func (t *Tokens) CreateSession(ctx context.Context, s domain.Session, rt domain.RefreshToken) error {
+	return withinTx(ctx, t.db, func(ctx context.Context, q querier) error {
+		if _, err := q.ExecContext(ctx,
+			"INSERT INTO sessions (id, user_id, device, ip, user_agent) values ($1, $2, $3, $4, $5)",
+			s.ID, s.UserID, s.Device, s.IP, s.UserAgent); err != nil {
+			return err
+		}
 
-	return err
+		_, err := q.ExecContext(ctx,
+			"INSERT INTO refresh_tokens (user_id, token_hash, family_id, expires_at) values ($1, $2, $3, $4)",
+			rt.UserId, rt.Token, rt.FamilyID, rt.ExpiresAt)
+
+		return err
+	})
 }
 
-func (t *Tokens) GetRefreshedToken(ctx context.Context, token string) (domain.RefreshToken, error) {
+func (t *Tokens) GetByToken(ctx context.Context, tokenHash string) (domain.RefreshToken, error) {
 	var rt domain.RefreshToken
-	if err := t.db.QueryRow(
-		"SELECT id, user_id, token, expires_at FROM refresh_tokens WHERE token=$1", token).Scan(
-		&rt.Id, &rt.UserId, &rt.Token, &rt.ExpiresAt); err != nil {
-		return rt, err
-	}
+	err := conn(ctx, t.db).QueryRowContext(ctx,
+		`SELECT id, user_id, token_hash, family_id, expires_at, created_at, rotated_at, revoked_at
+		FROM refresh_tokens WHERE token_hash=$1`, tokenHash).
+		Scan(&rt.Id, &rt.UserId, &rt.Token, &rt.FamilyID, &rt.ExpiresAt, &rt.CreatedAt, &rt.RotatedAt, &rt.RevokedAt)
 
-	_, err := t.db.Exec("DELETE FROM refresh_tokens WHERE user_id=$1", rt.UserId)
+	if err == sql.ErrNoRows {
+		return rt, domain.ErrRefreshTokenNotFound
+	}
 
 	return rt, err
 }
+
+// Rotate marks the token with oldId as rotated, stores its successor and
+// touches the session in the same transaction. If the old token has been
+// rotated or revoked concurrently, nothing is stored and
+// domain.ErrRefreshTokenReused is returned.
+func (t *Tokens) Rotate(ctx context.Context, oldId int64, next domain.RefreshToken, client domain.ClientInfo) error {
+	return withinTx(ctx, t.db, func(ctx context.Context, q querier) error {
+		res, err := q.ExecContext(ctx,
+			`UPDATE refresh_tokens SET rotated_at=now()
+			WHERE id=$1 AND rotated_at IS NULL AND revoked_at IS NULL`, oldId)
+		if err != nil {
+			return err
+		}
+
+		if err := checkAffected(res, domain.ErrRefreshTokenReused); err != nil {
+			return err
+		}
+
+		if _, err := q.ExecContext(ctx,
+			"INSERT INTO refresh_tokens (user_id, token_hash, family_id, expires_at) values ($1, $2, $3, $4)",
+			next.UserId, next.Token, next.FamilyID, next.ExpiresAt); err != nil {
+			return err
+		}
+
+		_, err = q.ExecContext(ctx,
+			"UPDATE sessions SET last_used_at=now(), ip=$1, user_agent=$2 WHERE id=$3",
+			nullString(client.IP), nullString(client.UserAgent), next.FamilyID)
+
+		return err
+	})
+}
+
+// RevokeFamily revokes the session and every refresh token of its family.
+func (t *Tokens) RevokeFamily(ctx context.Context, familyId string) error {
+	return withinTx(ctx, t.db, func(ctx context.Context, q querier) error {
+		if _, err := q.ExecContext(ctx,
+			"UPDATE sessions SET revoked_at=now() WHERE id=$1 AND revoked_at IS NULL", familyId); err != nil {
+			return err
+		}
+
+		_, err := q.ExecContext(ctx,
+			"UPDATE refresh_tokens SET revoked_at=now() WHERE family_id=$1 AND revoked_at IS NULL", familyId)
+
+		return err
+	})
+}
+
+// RevokeSession revokes a session of the user. Sessions of other users are
+// reported as not found.
+func (t *Tokens) RevokeSession(ctx context.Context, userId int64, sessionId string) error {
+	var exists bool
+	if err := conn(ctx, t.db).QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM sessions WHERE id=$1 AND user_id=$2 AND revoked_at IS NULL)",
+		sessionId, userId).Scan(&exists); err != nil {
+		return err
+	}
+
+	if !exists {
+		return domain.ErrSessionNotFound
+	}
+
+	return t.RevokeFamily(ctx, sessionId)
+}
+
+func (t *Tokens) RevokeAllSessions(ctx context.Context, userId int64) error {
+	return withinTx(ctx, t.db, func(ctx context.Context, q querier) error {
+		if _, err := q.ExecContext(ctx,
+			"UPDATE sessions SET revoked_at=now() WHERE user_id=$1 AND revoked_at IS NULL", userId); err != nil {
+			return err
+		}
+
+		_, err := q.ExecContext(ctx,
+			"UPDATE refresh_tokens SET revoked_at=now() WHERE user_id=$1 AND revoked_at IS NULL", userId)
+
+		return err
+	})
+}
+
+func (t *Tokens) GetSessions(ctx context.Context, userId int64) ([]domain.Session, error) {
+	rows, err := conn(ctx, t.db).QueryContext(ctx, `
+		SELECT id, user_id, device, ip, user_agent, created_at, last_used_at
+		FROM sessions
+		WHERE user_id=$1 AND revoked_at IS NULL
+		ORDER BY last_used_at DESC`, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := make([]domain.Session, 0)
+
+	for rows.Next() {
+		var s domain.Session
+		if err := rows.Scan(&s.ID, &s.UserID, &s.Device, &s.IP, &s.UserAgent, &s.CreatedAt, &s.LastUsedAt); err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, s)
+	}
+
+	return sessions, rows.Err()
+}
+
+// RevokeAccessToken puts the jti of an access token on the denylist until the
+// token expires. Entries of already expired tokens are dropped on the way.
+func (t *Tokens) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	q := conn(ctx, t.db)
+
+	if _, err := q.ExecContext(ctx,
+		"DELETE FROM revoked_access_tokens WHERE expires_at < now()"); err != nil {
+		return err
+	}
+
+	_, err := q.ExecContext(ctx,
+		"INSERT INTO revoked_access_tokens (jti, expires_at) values ($1, $2) ON CONFLICT (jti) DO NOTHING",
+		jti, expiresAt)
+
+	return err
+}
+
+// IsAccessTokenRevoked reports whether the access token is on the denylist or
+// belongs to a revoked session.
+func (t *Tokens) IsAccessTokenRevoked(ctx context.Context, jti string, sessionId string) (bool, error) {
+	var revoked bool
+	err := conn(ctx, t.db).QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM revoked_access_tokens WHERE jti=$1)
+			OR EXISTS (SELECT 1 FROM sessions WHERE id=$2 AND revoked_at IS NOT NULL)`,
+		jti, sessionId).Scan(&revoked)
+
+	return revoked, err
+}
+
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
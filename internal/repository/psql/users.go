@@ -33,7 +33,7 @@ func (u *Users) Create(ctx context.Context, user domain.User) (int64, error) {
 
 	var id int64
 
-	err := u.db.QueryRow(query, args...).Scan(&id)
+	err := conn(ctx, u.db).QueryRowContext(ctx, query, args...).Scan(&id)
 	if err != nil {
 		return 0, err
 	}
@@ -41,12 +41,11 @@ func (u *Users) Create(ctx context.Context, user domain.User) (int64, error) {
 	return id, err
 }
 
-func (u *Users) GetByCredentials(ctx context.Context, email string, hpass string) (domain.User, error) {
+func (u *Users) GetByEmail(ctx context.Context, email string) (domain.User, error) {
 	var user domain.User
-	err := u.db.QueryRow(
-		"SELECT id, nickname, email, password, registered_at FROM users WHERE email=$1 AND password=$2",
-		email, hpass).
-		Scan(&user.Id, &user.Nickname, &user.Email, &user.Password, &user.Registered_at)
+	err := conn(ctx, u.db).QueryRowContext(ctx,
+		"SELECT id, nickname, email, password, role, registered_at FROM users WHERE email=$1", email).
+		Scan(&user.Id, &user.Nickname, &user.Email, &user.Password, &user.Role, &user.Registered_at)
 
 	if err == sql.ErrNoRows {
 		return user, domain.ErrUserNotFound
@@ -54,3 +53,34 @@ func (u *Users) GetByCredentials(ctx context.Context, email string, hpass string
 
 	return user, err
 }
+
+func (u *Users) GetByID(ctx context.Context, id int64) (domain.User, error) {
+	var user domain.User
+	err := conn(ctx, u.db).QueryRowContext(ctx,
+		"SELECT id, nickname, email, password, role, registered_at FROM users WHERE id=$1", id).
+		Scan(&user.Id, &user.Nickname, &user.Email, &user.Password, &user.Role, &user.Registered_at)
+
+	if err == sql.ErrNoRows {
+		return user, domain.ErrUserNotFound
+	}
+
+	return user, err
+}
+
+func (u *Users) UpdateRole(ctx context.Context, id int64, role domain.Role) error {
+	res, err := conn(ctx, u.db).ExecContext(ctx, "UPDATE users SET role=$1 WHERE id=$2", role, id)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(res, domain.ErrUserNotFound)
+}
+
+func (u *Users) UpdatePassword(ctx context.Context, id int64, hpass string) error {
+	res, err := conn(ctx, u.db).ExecContext(ctx, "UPDATE users SET password=$1 WHERE id=$2", hpass, id)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(res, domain.ErrUserNotFound)
+}
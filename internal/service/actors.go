@@ -2,44 +2,477 @@ package service
 
 import (
 	"context"
+	"errors"
+	"reflect"
+	"time"
 
 	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	audit "github.com/AngelicaNice/auditlog_mq/pkg/domain"
 )
 
 type ActorsRepository interface {
 	Create(ctx context.Context, actor domain.ActorInput) (int64, error)
 	GetByID(ctx context.Context, id int64) (domain.Actor, error)
-	GetAllActors(ctx context.Context) ([]domain.Actor, error)
-	Update(ctx context.Context, id int64, info domain.UpdateActorInfo) error
-	Delete(ctx context.Context, id int64) error
+	GetAllActors(ctx context.Context, opts domain.ActorsListOptions) (domain.ActorsPage, error)
+	Update(ctx context.Context, id, version int64, info domain.UpdateActorInfo) error
+	Replace(ctx context.Context, id, version int64, actor domain.ActorInput) error
+	Delete(ctx context.Context, id, version int64) error
+	GetDeleted(ctx context.Context) ([]domain.DeletedActor, error)
+	GetDeletedByID(ctx context.Context, id int64) (domain.DeletedActor, error)
+	Restore(ctx context.Context, id int64) error
+	Purge(ctx context.Context, id int64) error
+	PurgeDeleted(ctx context.Context, retention time.Duration, limit int) ([]domain.DeletedActor, error)
+	AddRevision(ctx context.Context, rev domain.ActorRevision) error
+	GetRevisions(ctx context.Context, actorId int64) ([]domain.ActorRevision, error)
+	GetRevision(ctx context.Context, actorId, revision int64) (domain.ActorRevision, error)
+	Search(ctx context.Context, input domain.ActorsSearchInput) ([]domain.ActorSearchResult, error)
+	GetByKey(ctx context.Context, name, surname string, birthYear int) (domain.Actor, error)
+	Upsert(ctx context.Context, actor domain.ActorInput) (int64, error)
+	CreateBatch(ctx context.Context, actors []domain.ActorInput) ([]domain.Actor, error)
+	Export(ctx context.Context, fn func(actor domain.Actor) error) error
 }
 
 type Actors struct {
-	repo ActorsRepository
+	repo   ActorsRepository
+	outbox OutboxRepository
+	tx     Transactor
 }
 
-func NewActors(repo ActorsRepository) *Actors {
+func NewActors(repo ActorsRepository, ob OutboxRepository, tx Transactor) *Actors {
 	return &Actors{
-		repo: repo,
+		repo:   repo,
+		outbox: ob,
+		tx:     tx,
 	}
 }
 
 func (a *Actors) Create(ctx context.Context, actor domain.ActorInput) (int64, error) {
-	return a.repo.Create(ctx, actor)
+	var id int64
+
+	err := a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		id, err = a.repo.Create(ctx, actor)
+		if err != nil {
+			return err
+		}
+
+		created, err := a.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := a.addRevision(ctx, domain.RevisionCreate, created); err != nil {
+			return err
+		}
+
+		return addChangeLog(ctx, a.outbox, audit.LogItem{
+			Action:    "ACTION_CREATE",
+			Entity:    "ENTITY_ACTOR",
+			EntityID:  id,
+			Timestamp: time.Now(),
+		}, nil, created)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
 }
 
 func (a *Actors) GetByID(ctx context.Context, id int64) (domain.Actor, error) {
 	return a.repo.GetByID(ctx, id)
 }
 
-func (a *Actors) GetAllActors(ctx context.Context) ([]domain.Actor, error) {
-	return a.repo.GetAllActors(ctx)
+func (a *Actors) GetAllActors(ctx context.Context, opts domain.ActorsListOptions) (domain.ActorsPage, error) {
+	return a.repo.GetAllActors(ctx, opts)
+}
+
+// Update changes the fields of info that aren't nil and returns the updated
+// actor. Unless version is domain.AnyVersion it fails with
+// domain.ErrActorModified if the actor has another version.
+func (a *Actors) Update(ctx context.Context, id, version int64, info domain.UpdateActorInfo) (domain.Actor, error) {
+	var updated domain.Actor
+
+	err := a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		old, err := a.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if info.RestYear != nil && *info.RestYear < old.BirthYear {
+			return &domain.ValidationError{Fields: []domain.FieldError{{
+				Field:   "rest_year",
+				Message: "must not be less than birth_year",
+			}}}
+		}
+
+		if err := a.repo.Update(ctx, id, version, info); err != nil {
+			return err
+		}
+
+		if updated, err = a.repo.GetByID(ctx, id); err != nil {
+			return err
+		}
+
+		// an update without fields keeps the version
+		if updated.Version != old.Version {
+			if err := a.addRevision(ctx, domain.RevisionUpdate, updated); err != nil {
+				return err
+			}
+		}
+
+		return addChangeLog(ctx, a.outbox, audit.LogItem{
+			Action:    "ACTION_UPDATE",
+			Entity:    "ENTITY_ACTOR",
+			EntityID:  id,
+			Timestamp: time.Now(),
+		}, old, updated)
+	})
+
+	return updated, err
+}
+
+// Replace sets every field of the actor to the ones of input, the version is
+// checked as in Update.
+func (a *Actors) Replace(ctx context.Context, id, version int64, input domain.ActorInput) (domain.Actor, error) {
+	return a.patch(ctx, id, version, domain.RevisionUpdate, func(domain.Actor) (domain.ActorInput, error) {
+		return input, nil
+	})
+}
+
+// Patch replaces the actor with what patch makes of its current state, the
+// actor is read and written in one transaction and the version is checked as
+// in Update.
+func (a *Actors) Patch(ctx context.Context, id, version int64,
+	patch func(actor domain.Actor) (domain.ActorInput, error),
+) (domain.Actor, error) {
+	return a.patch(ctx, id, version, domain.RevisionUpdate, patch)
+}
+
+// Revert sets the actor back to the state of the revision, which adds a new
+// revision. The version is checked as in Update.
+func (a *Actors) Revert(ctx context.Context, id, revision, version int64) (domain.Actor, error) {
+	rev, err := a.repo.GetRevision(ctx, id, revision)
+	if err != nil {
+		return domain.Actor{}, err
+	}
+
+	return a.patch(ctx, id, version, domain.RevisionRevert, func(actor domain.Actor) (domain.ActorInput, error) {
+		// the rules may have changed since the revision was made
+		return rev.Actor, rev.Actor.ValidateChanged(actor.Input())
+	})
+}
+
+// patch replaces the actor as Patch does and adds a revision with the action.
+func (a *Actors) patch(ctx context.Context, id, version int64, action string,
+	patch func(actor domain.Actor) (domain.ActorInput, error),
+) (domain.Actor, error) {
+	var updated domain.Actor
+
+	err := a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		old, err := a.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		input, err := patch(old)
+		if err != nil {
+			return err
+		}
+
+		if err := a.repo.Replace(ctx, id, version, input); err != nil {
+			return err
+		}
+
+		if updated, err = a.repo.GetByID(ctx, id); err != nil {
+			return err
+		}
+
+		if err := a.addRevision(ctx, action, updated); err != nil {
+			return err
+		}
+
+		return addChangeLog(ctx, a.outbox, audit.LogItem{
+			Action:    "ACTION_UPDATE",
+			Entity:    "ENTITY_ACTOR",
+			EntityID:  id,
+			Timestamp: time.Now(),
+		}, old, updated)
+	})
+
+	return updated, err
+}
+
+// Delete moves the actor to the trash, the version is checked as in Update.
+func (a *Actors) Delete(ctx context.Context, id, version int64) error {
+	return a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		old, err := a.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := a.repo.Delete(ctx, id, version); err != nil {
+			return err
+		}
+
+		deleted, err := a.repo.GetDeletedByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := a.addRevision(ctx, domain.RevisionDelete, deleted.Actor); err != nil {
+			return err
+		}
+
+		return addChangeLog(ctx, a.outbox, audit.LogItem{
+			Action:    "ACTION_DELETE",
+			Entity:    "ENTITY_ACTOR",
+			EntityID:  id,
+			Timestamp: time.Now(),
+		}, old, nil)
+	})
+}
+
+// GetDeleted returns the actors in the trash.
+func (a *Actors) GetDeleted(ctx context.Context) ([]domain.DeletedActor, error) {
+	return a.repo.GetDeleted(ctx)
+}
+
+// Restore takes the actor out of the trash and returns it.
+func (a *Actors) Restore(ctx context.Context, id int64) (domain.Actor, error) {
+	var restored domain.Actor
+
+	err := a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := a.repo.Restore(ctx, id); err != nil {
+			return err
+		}
+
+		var err error
+		if restored, err = a.repo.GetByID(ctx, id); err != nil {
+			return err
+		}
+
+		if err := a.addRevision(ctx, domain.RevisionRestore, restored); err != nil {
+			return err
+		}
+
+		return addChangeLog(ctx, a.outbox, audit.LogItem{
+			Action:    "ACTION_RESTORE",
+			Entity:    "ENTITY_ACTOR",
+			EntityID:  id,
+			Timestamp: time.Now(),
+		}, nil, restored)
+	})
+
+	return restored, err
+}
+
+// Purge permanently deletes the actor from the trash.
+func (a *Actors) Purge(ctx context.Context, id int64) error {
+	return a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		old, err := a.repo.GetDeletedByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := a.repo.Purge(ctx, id); err != nil {
+			return err
+		}
+
+		return addChangeLog(ctx, a.outbox, audit.LogItem{
+			Action:    "ACTION_PURGE",
+			Entity:    "ENTITY_ACTOR",
+			EntityID:  id,
+			Timestamp: time.Now(),
+		}, old.Actor, nil)
+	})
+}
+
+// PurgeDeleted permanently deletes at most limit actors that have been in the
+// trash for longer than retention and returns how many it deleted.
+func (a *Actors) PurgeDeleted(ctx context.Context, retention time.Duration, limit int) (int, error) {
+	var purged []domain.DeletedActor
+
+	err := a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		if purged, err = a.repo.PurgeDeleted(ctx, retention, limit); err != nil {
+			return err
+		}
+
+		for _, actor := range purged {
+			if err := addChangeLog(ctx, a.outbox, audit.LogItem{
+				Action:    "ACTION_PURGE",
+				Entity:    "ENTITY_ACTOR",
+				EntityID:  actor.ID,
+				Timestamp: time.Now(),
+			}, actor.Actor, nil); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(purged), nil
+}
+
+func (a *Actors) Search(ctx context.Context, input domain.ActorsSearchInput) ([]domain.ActorSearchResult, error) {
+	return a.repo.Search(ctx, input)
+}
+
+// Import upserts the actors in one transaction, identifying them by name,
+// surname and birth year. Actors that didn't change are not audited.
+func (a *Actors) Import(ctx context.Context, actors []domain.ActorInput) error {
+	return a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		for _, actor := range actors {
+			if err := a.upsert(ctx, actor); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (a *Actors) upsert(ctx context.Context, actor domain.ActorInput) error {
+	action := "ACTION_UPDATE"
+
+	old, err := a.repo.GetByKey(ctx, actor.Name, actor.Surname, actor.BirthYear)
+	if errors.Is(err, domain.ErrActorNotFound) {
+		action = "ACTION_CREATE"
+	} else if err != nil {
+		return err
+	}
+
+	id, err := a.repo.Upsert(ctx, actor)
+	if err != nil {
+		return err
+	}
+
+	upserted, err := a.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	logItem := audit.LogItem{
+		Action:    action,
+		Entity:    "ENTITY_ACTOR",
+		EntityID:  id,
+		Timestamp: time.Now(),
+	}
+
+	if action == "ACTION_CREATE" {
+		if err := a.addRevision(ctx, domain.RevisionCreate, upserted); err != nil {
+			return err
+		}
+
+		return addChangeLog(ctx, a.outbox, logItem, nil, upserted)
+	}
+
+	if reflect.DeepEqual(old, upserted) {
+		return nil
+	}
+
+	if err := a.addRevision(ctx, domain.RevisionUpdate, upserted); err != nil {
+		return err
+	}
+
+	return addChangeLog(ctx, a.outbox, logItem, old, upserted)
+}
+
+// CreateBatch creates all the actors or none of them.
+func (a *Actors) CreateBatch(ctx context.Context, actors []domain.ActorInput) ([]domain.Actor, error) {
+	var created []domain.Actor
+
+	err := a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		created, err = a.repo.CreateBatch(ctx, actors)
+		if err != nil {
+			return err
+		}
+
+		for _, actor := range created {
+			if err := a.addRevision(ctx, domain.RevisionCreate, actor); err != nil {
+				return err
+			}
+
+			if err := addChangeLog(ctx, a.outbox, audit.LogItem{
+				Action:    "ACTION_CREATE",
+				Entity:    "ENTITY_ACTOR",
+				EntityID:  actor.ID,
+				Timestamp: time.Now(),
+			}, nil, actor); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
 }
 
-func (a *Actors) Update(ctx context.Context, id int64, info domain.UpdateActorInfo) error {
-	return a.repo.Update(ctx, id, info)
+func (a *Actors) Export(ctx context.Context, fn func(actor domain.Actor) error) error {
+	return a.repo.Export(ctx, fn)
 }
 
-func (a *Actors) Delete(ctx context.Context, id int64) error {
-	return a.repo.Delete(ctx, id)
+// GetRevisions returns the revisions of the actor, the latest first.
+func (a *Actors) GetRevisions(ctx context.Context, id int64) ([]domain.ActorRevision, error) {
+	return a.repo.GetRevisions(ctx, id)
+}
+
+func (a *Actors) GetRevision(ctx context.Context, id, revision int64) (domain.ActorRevision, error) {
+	return a.repo.GetRevision(ctx, id, revision)
+}
+
+// DiffRevisions returns the fields of the actor changed between the
+// revisions from and to.
+func (a *Actors) DiffRevisions(ctx context.Context, id, from, to int64) (domain.ActorRevisionsDiff, error) {
+	fromRev, err := a.repo.GetRevision(ctx, id, from)
+	if err != nil {
+		return domain.ActorRevisionsDiff{}, err
+	}
+
+	toRev, err := a.repo.GetRevision(ctx, id, to)
+	if err != nil {
+		return domain.ActorRevisionsDiff{}, err
+	}
+
+	changes, err := diff(fromRev.Actor, toRev.Actor)
+	if err != nil {
+		return domain.ActorRevisionsDiff{}, err
+	}
+
+	return domain.ActorRevisionsDiff{
+		ActorID: id,
+		From:    from,
+		To:      to,
+		Changes: changes,
+	}, nil
+}
+
+// addRevision stores the state of the actor after a change made by the user
+// from ctx. Called within the transaction of the change.
+func (a *Actors) addRevision(ctx context.Context, action string, actor domain.Actor) error {
+	rev := domain.ActorRevision{
+		ActorID:  actor.ID,
+		Revision: actor.Version,
+		Action:   action,
+		Actor:    actor.Input(),
+	}
+
+	if userId, ok := domain.UserIDFromContext(ctx); ok {
+		rev.UserID = &userId
+	}
+
+	return a.repo.AddRevision(ctx, rev)
 }
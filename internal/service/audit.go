@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/transport/mq"
+	audit "github.com/AngelicaNice/auditlog_mq/pkg/domain"
+	log "github.com/sirupsen/logrus"
+)
+
+// AuditSink is where the relay delivers audit events to: RabbitMQ, the
+// AuditLog service, a file, or several of them. Only the relay publishes to
+// it, the services add events to the outbox, which buffers them while the
+// sink is unavailable.
+type AuditSink interface {
+	Publish(ctx context.Context, event domain.Event) error
+}
+
+// Transactor runs fn in a database transaction; repositories called with the
+// context passed to fn take part in it.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type OutboxRepository interface {
+	Add(ctx context.Context, msg domain.OutboxMessage) error
+	Process(ctx context.Context, limit int,
+		publish func(ctx context.Context, msg domain.OutboxMessage) error) (int, error)
+}
+
+// auditEvent is a log item extended with the user who made the change and
+// what was changed. The extra fields are omitted when unknown, so consumers of
+// plain log items can read it as before.
+type auditEvent struct {
+	audit.LogItem
+	UserID  int64                         `json:"user_id,omitempty"`
+	Changes map[string]domain.FieldChange `json:"changes,omitempty"`
+}
+
+// addLog stores an audit log item in the outbox. Called within a transaction,
+// the item is published only if the audited change is committed.
+func addLog(ctx context.Context, outbox OutboxRepository, logItem audit.LogItem) error {
+	return addEvent(ctx, outbox, auditEvent{LogItem: logItem})
+}
+
+// addChangeLog stores an audit log item for a change of an entity made by the
+// user from ctx. old is nil for a created entity and new for a deleted one.
+func addChangeLog(ctx context.Context, outbox OutboxRepository, logItem audit.LogItem, old, new interface{}) error {
+	changes, err := diff(old, new)
+	if err != nil {
+		return err
+	}
+
+	userId, _ := domain.UserIDFromContext(ctx)
+
+	return addEvent(ctx, outbox, auditEvent{
+		LogItem: logItem,
+		UserID:  userId,
+		Changes: changes,
+	})
+}
+
+func addEvent(ctx context.Context, outbox OutboxRepository, event auditEvent) error {
+	body, err := mq.Serialize(event)
+	if err != nil {
+		return err
+	}
+
+	return outbox.Add(ctx, domain.OutboxMessage{
+		RoutingKey: routingKey(event.LogItem),
+		Subject:    subject(event.LogItem),
+		Payload:    body,
+	})
+}
+
+// routingKey builds the key an item is published with, e.g.
+// audit.user.register for ACTION_REGISTER on ENTITY_USER.
+func routingKey(logItem audit.LogItem) string {
+	entity := strings.ToLower(strings.TrimPrefix(logItem.Entity, "ENTITY_"))
+	action := strings.ToLower(strings.TrimPrefix(logItem.Action, "ACTION_"))
+
+	return "audit." + entity + "." + action
+}
+
+// subject names the entity an item is about, e.g. actor/42.
+func subject(logItem audit.LogItem) string {
+	entity := strings.ToLower(strings.TrimPrefix(logItem.Entity, "ENTITY_"))
+
+	return entity + "/" + strconv.FormatInt(logItem.EntityID, 10)
+}
+
+// diff compares the JSON representations of old and new field by field and
+// returns the fields whose values differ.
+func diff(old, new interface{}) (map[string]domain.FieldChange, error) {
+	oldFields, err := jsonFields(old)
+	if err != nil {
+		return nil, err
+	}
+
+	newFields, err := jsonFields(new)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]domain.FieldChange)
+
+	for name, oldValue := range oldFields {
+		if newValue := newFields[name]; !reflect.DeepEqual(oldValue, newValue) {
+			changes[name] = domain.FieldChange{Old: oldValue, New: newValue}
+		}
+	}
+
+	for name, newValue := range newFields {
+		if _, ok := oldFields[name]; !ok && newValue != nil {
+			changes[name] = domain.FieldChange{New: newValue}
+		}
+	}
+
+	return changes, nil
+}
+
+func jsonFields(v interface{}) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+	if v == nil {
+		return fields, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, json.Unmarshal(b, &fields)
+}
+
+// auditSchemaVersion is the version of the audit event payload, part of the
+// event type. Bump it on incompatible changes of auditEvent.
+const auditSchemaVersion = "v1"
+
+// OutboxRelay publishes the messages stored in the outbox as CloudEvents,
+// giving at-least-once delivery: a message is marked as sent only after the
+// sink accepted it, failed ones are retried. The event id is the id of the
+// message, so retries can be deduplicated by source and id.
+type OutboxRelay struct {
+	repo     OutboxRepository
+	sink     AuditSink
+	source   string
+	interval time.Duration
+	batch    int
+}
+
+func NewOutboxRelay(repo OutboxRepository, sink AuditSink, source string, interval time.Duration, batch int) *OutboxRelay {
+	return &OutboxRelay{
+		repo:     repo,
+		sink:     sink,
+		source:   source,
+		interval: interval,
+		batch:    batch,
+	}
+}
+
+// Run relays messages every interval until ctx is done.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.relay(ctx)
+		}
+	}
+}
+
+func (r *OutboxRelay) relay(ctx context.Context) {
+	for {
+		sent, err := r.repo.Process(ctx, r.batch, func(ctx context.Context, msg domain.OutboxMessage) error {
+			return r.sink.Publish(ctx, r.event(msg))
+		})
+		if err != nil {
+			log.WithField("outbox", "failed relaying messages").Error(err)
+
+			return
+		}
+
+		if sent < r.batch {
+			return
+		}
+	}
+}
+
+func (r *OutboxRelay) event(msg domain.OutboxMessage) domain.Event {
+	return domain.Event{
+		SpecVersion:     domain.CloudEventsSpecVersion,
+		ID:              strconv.FormatInt(msg.ID, 10),
+		Source:          r.source,
+		Type:            "hollywoodstars." + msg.RoutingKey + "." + auditSchemaVersion,
+		Subject:         msg.Subject,
+		Time:            msg.CreatedAt,
+		DataContentType: "application/json",
+		Data:            msg.Payload,
+		RoutingKey:      msg.RoutingKey,
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	audit "github.com/AngelicaNice/auditlog_mq/pkg/domain"
+)
+
+type FollowsRepository interface {
+	Follow(ctx context.Context, userId, actorId int64) (bool, error)
+	Unfollow(ctx context.Context, userId, actorId int64) (bool, error)
+	GetFollows(ctx context.Context, userId int64) ([]domain.FollowedActor, error)
+	CountFollowers(ctx context.Context, actorId int64) (int64, error)
+}
+
+type Follows struct {
+	repo   FollowsRepository
+	outbox OutboxRepository
+	tx     Transactor
+}
+
+func NewFollows(repo FollowsRepository, ob OutboxRepository, tx Transactor) *Follows {
+	return &Follows{
+		repo:   repo,
+		outbox: ob,
+		tx:     tx,
+	}
+}
+
+// Follow audits the follow only if the user didn't follow the actor yet.
+func (f *Follows) Follow(ctx context.Context, userId, actorId int64) error {
+	return f.tx.WithinTx(ctx, func(ctx context.Context) error {
+		followed, err := f.repo.Follow(ctx, userId, actorId)
+		if err != nil || !followed {
+			return err
+		}
+
+		return addEvent(ctx, f.outbox, auditEvent{
+			LogItem: audit.LogItem{
+				Action:    "ACTION_FOLLOW",
+				Entity:    "ENTITY_ACTOR",
+				EntityID:  actorId,
+				Timestamp: time.Now(),
+			},
+			UserID: userId,
+		})
+	})
+}
+
+// Unfollow audits the unfollow only if the user followed the actor.
+func (f *Follows) Unfollow(ctx context.Context, userId, actorId int64) error {
+	return f.tx.WithinTx(ctx, func(ctx context.Context) error {
+		unfollowed, err := f.repo.Unfollow(ctx, userId, actorId)
+		if err != nil || !unfollowed {
+			return err
+		}
+
+		return addEvent(ctx, f.outbox, auditEvent{
+			LogItem: audit.LogItem{
+				Action:    "ACTION_UNFOLLOW",
+				Entity:    "ENTITY_ACTOR",
+				EntityID:  actorId,
+				Timestamp: time.Now(),
+			},
+			UserID: userId,
+		})
+	})
+}
+
+func (f *Follows) GetFollows(ctx context.Context, userId int64) ([]domain.FollowedActor, error) {
+	return f.repo.GetFollows(ctx, userId)
+}
+
+func (f *Follows) CountFollowers(ctx context.Context, actorId int64) (int64, error) {
+	return f.repo.CountFollowers(ctx, actorId)
+}
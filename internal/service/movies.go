@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+)
+
+type MoviesRepository interface {
+	Create(ctx context.Context, movie domain.MovieInput) (int64, error)
+	GetByID(ctx context.Context, id int64) (domain.Movie, error)
+	GetAll(ctx context.Context) ([]domain.Movie, error)
+	Update(ctx context.Context, id int64, info domain.UpdateMovieInfo) error
+	Delete(ctx context.Context, id int64) error
+	AddRole(ctx context.Context, movieId int64, role domain.RoleInput) (int64, error)
+	DeleteRole(ctx context.Context, movieId, roleId int64) error
+	GetCast(ctx context.Context, movieId int64) ([]domain.CastMember, error)
+	GetFilmography(ctx context.Context, actorId int64) ([]domain.FilmographyItem, error)
+}
+
+type Movies struct {
+	repo MoviesRepository
+}
+
+func NewMovies(repo MoviesRepository) *Movies {
+	return &Movies{
+		repo: repo,
+	}
+}
+
+func (m *Movies) Create(ctx context.Context, movie domain.MovieInput) (int64, error) {
+	return m.repo.Create(ctx, movie)
+}
+
+func (m *Movies) GetByID(ctx context.Context, id int64) (domain.Movie, error) {
+	return m.repo.GetByID(ctx, id)
+}
+
+func (m *Movies) GetAll(ctx context.Context) ([]domain.Movie, error) {
+	return m.repo.GetAll(ctx)
+}
+
+func (m *Movies) Update(ctx context.Context, id int64, info domain.UpdateMovieInfo) error {
+	return m.repo.Update(ctx, id, info)
+}
+
+func (m *Movies) Delete(ctx context.Context, id int64) error {
+	return m.repo.Delete(ctx, id)
+}
+
+func (m *Movies) AddRole(ctx context.Context, movieId int64, role domain.RoleInput) (int64, error) {
+	return m.repo.AddRole(ctx, movieId, role)
+}
+
+func (m *Movies) DeleteRole(ctx context.Context, movieId, roleId int64) error {
+	return m.repo.DeleteRole(ctx, movieId, roleId)
+}
+
+func (m *Movies) GetCast(ctx context.Context, movieId int64) ([]domain.CastMember, error) {
+	return m.repo.GetCast(ctx, movieId)
+}
+
+func (m *Movies) GetFilmography(ctx context.Context, actorId int64) ([]domain.FilmographyItem, error) {
+	return m.repo.GetFilmography(ctx, actorId)
+}
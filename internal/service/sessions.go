@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	audit "github.com/AngelicaNice/auditlog_mq/pkg/domain"
+)
+
+// Logout ends the session the access token was issued for and rejects the
+// access token itself right away.
+func (u *Users) Logout(ctx context.Context, claims domain.TokenClaims) error {
+	return u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if claims.SessionID != "" {
+			if err := u.trepo.RevokeFamily(ctx, claims.SessionID); err != nil {
+				return err
+			}
+		}
+
+		if err := u.revokeAccessToken(ctx, claims); err != nil {
+			return err
+		}
+
+		return addLog(ctx, u.outbox, audit.LogItem{
+			Action:    "ACTION_LOGOUT",
+			Entity:    "ENTITY_USER",
+			EntityID:  claims.UserID,
+			Timestamp: time.Now(),
+		})
+	})
+}
+
+// LogoutAll ends every session of the user.
+func (u *Users) LogoutAll(ctx context.Context, claims domain.TokenClaims) error {
+	return u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.trepo.RevokeAllSessions(ctx, claims.UserID); err != nil {
+			return err
+		}
+
+		if err := u.revokeAccessToken(ctx, claims); err != nil {
+			return err
+		}
+
+		return addLog(ctx, u.outbox, audit.LogItem{
+			Action:    "ACTION_LOGOUT_ALL",
+			Entity:    "ENTITY_USER",
+			EntityID:  claims.UserID,
+			Timestamp: time.Now(),
+		})
+	})
+}
+
+func (u *Users) GetSessions(ctx context.Context, claims domain.TokenClaims) ([]domain.Session, error) {
+	sessions, err := u.trepo.GetSessions(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == claims.SessionID
+	}
+
+	return sessions, nil
+}
+
+func (u *Users) RevokeSession(ctx context.Context, userId int64, sessionId string) error {
+	return u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.trepo.RevokeSession(ctx, userId, sessionId); err != nil {
+			return err
+		}
+
+		return addLog(ctx, u.outbox, audit.LogItem{
+			Action:    "ACTION_SESSION_REVOKE",
+			Entity:    "ENTITY_USER",
+			EntityID:  userId,
+			Timestamp: time.Now(),
+		})
+	})
+}
+
+func (u *Users) revokeAccessToken(ctx context.Context, claims domain.TokenClaims) error {
+	if claims.TokenID == "" {
+		return nil
+	}
+
+	return u.trepo.RevokeAccessToken(ctx, claims.TokenID, claims.ExpiresAt)
+}
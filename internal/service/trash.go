@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// TrashPurger permanently deletes the actors that have been in the trash for
+// longer than the retention period.
+type TrashPurger struct {
+	actors    *Actors
+	retention time.Duration
+	interval  time.Duration
+	batch     int
+}
+
+func NewTrashPurger(actors *Actors, retention, interval time.Duration, batch int) *TrashPurger {
+	return &TrashPurger{
+		actors:    actors,
+		retention: retention,
+		interval:  interval,
+		batch:     batch,
+	}
+}
+
+// Run purges the trash every interval until ctx is done.
+func (p *TrashPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.purge(ctx)
+		}
+	}
+}
+
+func (p *TrashPurger) purge(ctx context.Context) {
+	for {
+		purged, err := p.actors.PurgeDeleted(ctx, p.retention, p.batch)
+		if err != nil {
+			log.WithField("trash", "failed purging actors").Error(err)
+
+			return
+		}
+
+		if purged > 0 {
+			log.WithField("trash", "purged actors").Info(purged)
+		}
+
+		if purged < p.batch {
+			return
+		}
+	}
+}
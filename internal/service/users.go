@@ -2,16 +2,18 @@ package service
 
 import (
 	"context"
-	"database/sql"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"math/rand"
 	"strconv"
 	"time"
+	"unicode/utf8"
 
 	//audit "github.com/AngelicaNice/AuditLog/pkg/domain"
 	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
-	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/transport/mq"
+	"github.com/AngelicaNice/HollywoodStarsCRUD/pkg/jwtkeys"
 	audit "github.com/AngelicaNice/auditlog_mq/pkg/domain"
 	"github.com/golang-jwt/jwt"
 	log "github.com/sirupsen/logrus"
@@ -19,39 +21,62 @@ import (
 
 type UsersRepository interface {
 	Create(ctx context.Context, user domain.User) (int64, error)
-	GetByCredentials(ctx context.Context, email string, hpass string) (domain.User, error)
+	GetByEmail(ctx context.Context, email string) (domain.User, error)
+	GetByID(ctx context.Context, id int64) (domain.User, error)
+	UpdateRole(ctx context.Context, id int64, role domain.Role) error
+	UpdatePassword(ctx context.Context, id int64, hpass string) error
 }
 
 type PasswordHasher interface {
 	Hash(password string) (string, error)
+	Verify(password, encoded string) (ok bool, needsRehash bool, err error)
 }
 
 type TokensRepository interface {
-	CreateToken(ctx context.Context, rt domain.RefreshToken) error
-	GetRefreshedToken(ctx context.Context, refreshToken string) (domain.RefreshToken, error)
+	CreateSession(ctx context.Context, s domain.Session, rt domain.RefreshToken) error
+	GetByToken(ctx context.Context, tokenHash string) (domain.RefreshToken, error)
+	Rotate(ctx context.Context, oldId int64, next domain.RefreshToken, client domain.ClientInfo) error
+	RevokeFamily(ctx context.Context, familyId string) error
+	RevokeSession(ctx context.Context, userId int64, sessionId string) error
+	RevokeAllSessions(ctx context.Context, userId int64) error
+	GetSessions(ctx context.Context, userId int64) ([]domain.Session, error)
+	RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsAccessTokenRevoked(ctx context.Context, jti string, sessionId string) (bool, error)
 }
 
-type Publisher interface {
-	Publish(ctx context.Context, body []byte) error
+// TokenKeys are the keys access tokens are signed and verified with.
+type TokenKeys interface {
+	SigningKey() jwtkeys.Key
+	Key(kid string) (jwtkeys.Key, error)
+	JWKS() jwtkeys.JWKS
 }
 
+// dummyPasswordHash is verified when signing in with an unknown email, so
+// that the response takes as long as for a wrong password. It has the default
+// argon2id parameters.
+const dummyPasswordHash = "$argon2id$v=19$m=65536,t=1,p=4$+0D0FTHdgz83IDK1UrLOVA$9mpEUpQqc246LweS+acGj3VE6kCVnbNJeXEjiqKgNKU"
+
 type Users struct {
-	repo      UsersRepository
-	trepo     TokensRepository
-	publisher Publisher
-	hash      PasswordHasher
-	secret    []byte
-	tokenTtl  time.Duration
+	repo     UsersRepository
+	trepo    TokensRepository
+	outbox   OutboxRepository
+	tx       Transactor
+	hash     PasswordHasher
+	keys     TokenKeys
+	tokenTtl time.Duration
 }
 
-func NewUsers(r UsersRepository, tr TokensRepository, pb Publisher, ph PasswordHasher, s []byte, tttl time.Duration) *Users {
+func NewUsers(r UsersRepository, tr TokensRepository, ob OutboxRepository, tx Transactor, ph PasswordHasher,
+	k TokenKeys, tttl time.Duration,
+) *Users {
 	return &Users{
-		repo:      r,
-		trepo:     tr,
-		publisher: pb,
-		hash:      ph,
-		secret:    s,
-		tokenTtl:  tttl,
+		repo:     r,
+		trepo:    tr,
+		outbox:   ob,
+		tx:       tx,
+		hash:     ph,
+		keys:     k,
+		tokenTtl: tttl,
 	}
 }
 
@@ -61,96 +86,132 @@ func (u *Users) Create(ctx context.Context, user domain.SignUpInput) (int64, err
 		return 0, err
 	}
 
-	id, err := u.repo.Create(ctx, domain.User{
-		Nickname:      user.Nickname,
-		Email:         user.Email,
-		Password:      pass,
-		Registered_at: time.Now(),
+	var id int64
+
+	err = u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		id, err = u.repo.Create(ctx, domain.User{
+			Nickname:      user.Nickname,
+			Email:         user.Email,
+			Password:      pass,
+			Registered_at: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+
+		return addLog(ctx, u.outbox, audit.LogItem{
+			Action:    "ACTION_REGISTER",
+			Entity:    "ENTITY_USER",
+			EntityID:  id,
+			Timestamp: time.Now(),
+		})
 	})
 	if err != nil {
 		return 0, err
 	}
 
-	logItem := audit.LogItem{
-		Action:    "ACTION_REGISTER",
-		Entity:    "ENTITY_USER",
-		EntityID:  id,
-		Timestamp: time.Now(),
-	}
+	return id, nil
+}
 
-	fmt.Println("SRART SERIALIZING")
+func (u *Users) GetToken(ctx context.Context, input domain.SignInInput, client domain.ClientInfo) (string, string, error) {
+	user, err := u.repo.GetByEmail(ctx, input.Email)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		_, _, _ = u.hash.Verify(input.Password, dummyPasswordHash)
 
-	body, err := mq.Serialize(logItem)
-	if err != nil {
-		log.WithField("serialize", "wrong body")
-		return id, err
+		return "", "", domain.ErrUserNotFound
 	}
 
-	fmt.Println("SRART PUBLISHING")
-
-	if err := u.publisher.Publish(ctx, body); err != nil {
-		log.WithField("logs:", "unsuccessful log sending")
+	if err != nil {
+		return "", "", err
 	}
 
-	return id, err
-}
-
-func (u *Users) GetToken(ctx context.Context, input domain.SignInInput) (string, string, error) {
-	hpass, err := u.hash.Hash(input.Password)
+	ok, needsRehash, err := u.hash.Verify(input.Password, user.Password)
 	if err != nil {
 		return "", "", err
 	}
 
-	user, err := u.repo.GetByCredentials(ctx, input.Email, hpass)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return "", "", domain.ErrUserNotFound
+	if !ok {
+		return "", "", domain.ErrUserNotFound
+	}
+
+	if needsRehash {
+		u.rehashPassword(ctx, user.Id, input.Password)
+	}
+
+	var accessToken, refreshToken string
+
+	err = u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		accessToken, refreshToken, err = u.GenerateTokens(ctx, user.Id, user.Role, client)
+		if err != nil {
+			return err
 		}
 
+		return addLog(ctx, u.outbox, audit.LogItem{
+			Action:    "ACTION_TOKEN_REQUEST",
+			Entity:    "ENTITY_USER",
+			EntityID:  user.Id,
+			Timestamp: time.Now(),
+		})
+	})
+	if err != nil {
 		return "", "", err
 	}
 
-	logItem := audit.LogItem{
-		Action:    "ACTION_TOKEN_REQUEST",
-		Entity:    "ENTITY_USER",
-		EntityID:  user.Id,
-		Timestamp: time.Now(),
-	}
+	return accessToken, refreshToken, nil
+}
 
-	body, err := mq.Serialize(logItem)
+// rehashPassword upgrades a legacy or outdated password hash after a successful
+// sign in. Failing to do so doesn't prevent the user from signing in.
+func (u *Users) rehashPassword(ctx context.Context, id int64, password string) {
+	hpass, err := u.hash.Hash(password)
 	if err != nil {
-		log.WithField("serialize to rabbitmq", "wrong body")
-		return u.GenerateTokens(ctx, user.Id)
+		log.WithField("rehash", "failed hashing password").Error(err)
+
+		return
 	}
 
-	if err = u.publisher.Publish(ctx, body); err != nil {
-		log.WithField("logs:", "unsuccessful log sending")
+	if err := u.repo.UpdatePassword(ctx, id, hpass); err != nil {
+		log.WithField("rehash", "failed updating password").Error(err)
 	}
+}
 
-	return u.GenerateTokens(ctx, user.Id)
+type tokenClaims struct {
+	jwt.StandardClaims
+	Role      domain.Role `json:"role"`
+	SessionID string      `json:"sid,omitempty"`
 }
 
-func (u *Users) GenerateTokens(ctx context.Context, userId int64) (string, string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
-		Subject:   strconv.Itoa(int(userId)),
-		IssuedAt:  time.Now().Unix(),
-		ExpiresAt: time.Now().Add(u.tokenTtl).Unix(),
-	})
+const refreshTokenTtl = time.Hour * 24 * 30
 
-	accessToken, err := token.SignedString(u.secret)
+// GenerateTokens issues an access token and a refresh token starting a new
+// session, i.e. a new token family.
+func (u *Users) GenerateTokens(ctx context.Context, userId int64, role domain.Role, client domain.ClientInfo) (string, string, error) {
+	sessionId, err := randomHex(16)
 	if err != nil {
 		return "", "", err
 	}
 
-	refreshToken, err := newRefreshToken()
+	accessToken, err := u.newAccessToken(userId, role, sessionId)
 	if err != nil {
 		return "", "", err
 	}
 
-	if err := u.trepo.CreateToken(ctx, domain.RefreshToken{
+	refreshToken, err := randomHex(32)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := u.trepo.CreateSession(ctx, domain.Session{
+		ID:        sessionId,
+		UserID:    userId,
+		Device:    optionalString(client.Device, 50),
+		IP:        optionalString(client.IP, 45),
+		UserAgent: optionalString(client.UserAgent, 255),
+	}, domain.RefreshToken{
 		UserId:    userId,
-		Token:     refreshToken,
-		ExpiresAt: time.Now().Add(time.Hour * 24 * 30),
+		Token:     hashRefreshToken(refreshToken),
+		FamilyID:  sessionId,
+		ExpiresAt: time.Now().Add(refreshTokenTtl),
 	}); err != nil {
 		return "", "", err
 	}
@@ -158,79 +219,237 @@ func (u *Users) GenerateTokens(ctx context.Context, userId int64) (string, strin
 	return accessToken, refreshToken, nil
 }
 
-func newRefreshToken() (string, error) {
-	b := make([]byte, 32)
+func (u *Users) newAccessToken(userId int64, role domain.Role, sessionId string) (string, error) {
+	jti, err := randomHex(16)
+	if err != nil {
+		return "", err
+	}
+
+	key := u.keys.SigningKey()
+
+	token := jwt.NewWithClaims(key.Method, tokenClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        jti,
+			Subject:   strconv.Itoa(int(userId)),
+			IssuedAt:  time.Now().Unix(),
+			ExpiresAt: time.Now().Add(u.tokenTtl).Unix(),
+		},
+		Role:      role,
+		SessionID: sessionId,
+	})
+	token.Header["kid"] = key.ID
 
-	s := rand.NewSource(time.Now().Unix())
-	r := rand.New(s)
+	return token.SignedString(key.Private)
+}
+
+func optionalString(s string, max int) *string {
+	if s == "" {
+		return nil
+	}
 
-	if _, err := r.Read(b); err != nil {
+	s = truncate(s, max)
+
+	return &s
+}
+
+// truncate cuts s to at most max characters, the limit varchar columns count
+// in, never splitting a multibyte character.
+func truncate(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+
+	return string([]rune(s)[:max])
+}
+
+// JWKS returns the public keys access tokens can be verified with.
+func (u *Users) JWKS() jwtkeys.JWKS {
+	return u.keys.JWKS()
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+
+	if _, err := rand.Read(b); err != nil {
 		return "", err
 	}
 
-	return fmt.Sprintf("%x", b), nil
+	return hex.EncodeToString(b), nil
+}
+
+// hashRefreshToken is what is stored in the database instead of the token.
+// Refresh tokens are random, so a plain SHA-256 is enough.
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
 }
 
-func (u *Users) ParseToken(ctx context.Context, token string) (int64, error) {
-	t, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return "", fmt.Errorf("unexpected method %v", token.Header["alg"])
+func (u *Users) ParseToken(ctx context.Context, token string) (domain.TokenClaims, error) {
+	var claims tokenClaims
+
+	t, err := jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (interface{}, error) {
+		kid, ok := token.Header["kid"].(string)
+		if !ok {
+			return nil, errors.New("missing kid")
+		}
+
+		key, err := u.keys.Key(kid)
+		if err != nil {
+			return nil, err
 		}
 
-		return u.secret, nil
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected method %v", token.Header["alg"])
+		}
+
+		return key.Public, nil
 	})
 	if err != nil {
-		return 0, err
+		return domain.TokenClaims{}, err
 	}
 
 	if !t.Valid {
-		return 0, err
+		return domain.TokenClaims{}, errors.New("invalid token")
 	}
 
-	claims, ok := t.Claims.(jwt.MapClaims)
-	if !ok {
-		return 0, errors.New("invalid claims")
+	id, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return domain.TokenClaims{}, errors.New("invalid id")
 	}
 
-	subj, ok := claims["sub"].(string)
-	if !ok {
-		return 0, errors.New("invalid sub")
+	revoked, err := u.trepo.IsAccessTokenRevoked(ctx, claims.Id, claims.SessionID)
+	if err != nil {
+		return domain.TokenClaims{}, err
 	}
 
-	id, err := strconv.Atoi(subj)
-	if err != nil {
-		return 0, errors.New("invalid id")
+	if revoked {
+		return domain.TokenClaims{}, domain.ErrAccessTokenRevoked
 	}
 
-	return int64(id), nil
+	role := claims.Role
+	if role == "" {
+		role = domain.RoleViewer
+	}
+
+	return domain.TokenClaims{
+		UserID:    int64(id),
+		Role:      role,
+		TokenID:   claims.Id,
+		SessionID: claims.SessionID,
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0),
+	}, nil
 }
 
-func (u *Users) RefreshToken(ctx context.Context, refreshToken string) (string, string, error) {
-	rtoken, err := u.trepo.GetRefreshedToken(ctx, refreshToken)
+// RefreshToken exchanges a refresh token for a new pair of tokens. The
+// presented token can be used only once: presenting an already rotated token
+// means it has leaked, so its whole family is revoked.
+func (u *Users) RefreshToken(ctx context.Context, refreshToken string, client domain.ClientInfo) (string, string, error) {
+	rtoken, err := u.trepo.GetByToken(ctx, hashRefreshToken(refreshToken))
 	if err != nil {
 		return "", "", err
 	}
 
+	if rtoken.RevokedAt != nil {
+		return "", "", domain.ErrRefreshTokenRevoked
+	}
+
+	if rtoken.RotatedAt != nil {
+		return "", "", u.revokeReusedFamily(ctx, rtoken)
+	}
+
 	if rtoken.ExpiresAt.Unix() < time.Now().Unix() {
 		return "", "", domain.ErrRefreshTokenExpired
 	}
 
-	logItem := audit.LogItem{
-		Action:    "ACTION_REFRESH_TOKEN",
-		Entity:    "ENTITY_USER",
-		EntityID:  rtoken.UserId,
-		Timestamp: time.Now(),
+	user, err := u.repo.GetByID(ctx, rtoken.UserId)
+	if err != nil {
+		return "", "", err
+	}
+
+	accessToken, err := u.newAccessToken(user.Id, user.Role, rtoken.FamilyID)
+	if err != nil {
+		return "", "", err
+	}
+
+	nextToken, err := randomHex(32)
+	if err != nil {
+		return "", "", err
 	}
 
-	body, err := mq.Serialize(logItem)
+	err = u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.trepo.Rotate(ctx, rtoken.Id, domain.RefreshToken{
+			UserId:    user.Id,
+			Token:     hashRefreshToken(nextToken),
+			FamilyID:  rtoken.FamilyID,
+			ExpiresAt: time.Now().Add(refreshTokenTtl),
+		}, domain.ClientInfo{
+			IP:        truncate(client.IP, 45),
+			UserAgent: truncate(client.UserAgent, 255),
+		}); err != nil {
+			return err
+		}
+
+		return addLog(ctx, u.outbox, audit.LogItem{
+			Action:    "ACTION_REFRESH_TOKEN",
+			Entity:    "ENTITY_USER",
+			EntityID:  user.Id,
+			Timestamp: time.Now(),
+		})
+	})
 	if err != nil {
-		log.WithField("serialize to rabbitmq", "wrong body")
-		return u.GenerateTokens(ctx, rtoken.UserId)
+		if errors.Is(err, domain.ErrRefreshTokenReused) {
+			return "", "", u.revokeReusedFamily(ctx, rtoken)
+		}
+
+		return "", "", err
 	}
 
-	if err = u.publisher.Publish(ctx, body); err != nil {
-		log.WithField("logs:", "unsuccessful log sending")
+	return accessToken, nextToken, nil
+}
+
+func (u *Users) revokeReusedFamily(ctx context.Context, rtoken domain.RefreshToken) error {
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.trepo.RevokeFamily(ctx, rtoken.FamilyID); err != nil {
+			return err
+		}
+
+		return addLog(ctx, u.outbox, audit.LogItem{
+			Action:    "ACTION_REFRESH_TOKEN_REUSE",
+			Entity:    "ENTITY_USER",
+			EntityID:  rtoken.UserId,
+			Timestamp: time.Now(),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return domain.ErrRefreshTokenReused
+}
+
+// UpdateRole sets the role of the user, the admin from ctx is audited as the
+// author of the change.
+func (u *Users) UpdateRole(ctx context.Context, id int64, role domain.Role) error {
+	type userRole struct {
+		Role domain.Role `json:"role"`
 	}
 
-	return u.GenerateTokens(ctx, rtoken.UserId)
+	return u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		user, err := u.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := u.repo.UpdateRole(ctx, id, role); err != nil {
+			return err
+		}
+
+		return addChangeLog(ctx, u.outbox, audit.LogItem{
+			Action:    "ACTION_ROLE_CHANGE",
+			Entity:    "ENTITY_USER",
+			EntityID:  id,
+			Timestamp: time.Now(),
+		}, userRole{Role: user.Role}, userRole{Role: role})
+	})
 }
@@ -2,9 +2,13 @@ package grpc_client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	audit "github.com/AngelicaNice/AuditLog/pkg/domain"
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -55,3 +59,40 @@ func (c *Client) SendLogRequest(ctx context.Context, req audit.LogItem) error {
 
 	return err
 }
+
+// Publish sends a serialized audit event to the AuditLog service. Events the
+// service has no action or entity for can never be delivered, so they are
+// dropped with a warning instead of being retried.
+func (c *Client) Publish(ctx context.Context, e domain.Event) error {
+	var event struct {
+		Action    string    `json:"action"`
+		Entity    string    `json:"entity"`
+		EntityID  int64     `json:"entity_id"`
+		Timestamp time.Time `json:"timestamp"`
+	}
+
+	if err := json.Unmarshal(e.Data, &event); err != nil {
+		return err
+	}
+
+	item := audit.LogItem{
+		Action:    event.Action,
+		Entity:    event.Entity,
+		EntityID:  event.EntityID,
+		Timestamp: event.Timestamp,
+	}
+
+	if _, err := audit.ToPbAction(item.Action); err != nil {
+		log.WithField("grpc audit", "unsupported action, dropped").Warn(item.Action)
+
+		return nil
+	}
+
+	if _, err := audit.ToPbEntity(item.Entity); err != nil {
+		log.WithField("grpc audit", "unsupported entity, dropped").Warn(item.Entity)
+
+		return nil
+	}
+
+	return c.SendLogRequest(ctx, item)
+}
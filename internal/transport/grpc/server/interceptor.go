@@ -0,0 +1,86 @@
+package grpc_server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	pb "github.com/AngelicaNice/HollywoodStarsCRUD/pkg/api/actors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// methodRoles are the roles required to call the methods, the same as for the
+// matching REST routes. Methods not listed are open to any signed in user.
+var methodRoles = map[string]domain.Role{
+	pb.ActorsService_Create_FullMethodName: domain.RoleEditor,
+	pb.ActorsService_Update_FullMethodName: domain.RoleEditor,
+	pb.ActorsService_Delete_FullMethodName: domain.RoleAdmin,
+}
+
+func (s *Server) unaryAuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	ctx, err := s.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (s *Server) streamAuthInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, err := s.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authServerStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate checks the bearer token from the authorization metadata the
+// same way the REST authMiddleware does and puts its claims into the context.
+func (s *Server) authenticate(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "empty authorization metadata")
+	}
+
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata")
+	}
+
+	claims, err := s.usersService.ParseToken(ctx, token)
+	if err != nil {
+		log.WithField("authInterceptor", "parsing token").Error(err)
+
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	if required, ok := methodRoles[method]; ok && !claims.Role.Allows(required) {
+		return nil, status.Error(codes.PermissionDenied, domain.ErrForbidden.Error())
+	}
+
+	ctx = domain.WithUserID(ctx, claims.UserID)
+	ctx = domain.WithRole(ctx, claims.Role)
+	ctx = domain.WithTokenClaims(ctx, claims)
+
+	return ctx, nil
+}
+
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,201 @@
+package grpc_server
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	pb "github.com/AngelicaNice/HollywoodStarsCRUD/pkg/api/actors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type Actors interface {
+	Create(ctx context.Context, actor domain.ActorInput) (int64, error)
+	GetByID(ctx context.Context, id int64) (domain.Actor, error)
+	GetAllActors(ctx context.Context, opts domain.ActorsListOptions) (domain.ActorsPage, error)
+	Update(ctx context.Context, id, version int64, info domain.UpdateActorInfo) (domain.Actor, error)
+	Delete(ctx context.Context, id, version int64) error
+}
+
+type Users interface {
+	ParseToken(ctx context.Context, token string) (domain.TokenClaims, error)
+}
+
+type Server struct {
+	pb.UnimplementedActorsServiceServer
+
+	actorsService Actors
+	usersService  Users
+}
+
+func NewServer(a Actors, u Users) *Server {
+	return &Server{
+		actorsService: a,
+		usersService:  u,
+	}
+}
+
+// Register creates a gRPC server with the auth interceptors and the
+// ActorsService registered on it.
+func (s *Server) Register(opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
+		grpc.UnaryInterceptor(s.unaryAuthInterceptor),
+		grpc.StreamInterceptor(s.streamAuthInterceptor))
+
+	srv := grpc.NewServer(opts...)
+	pb.RegisterActorsServiceServer(srv, s)
+
+	return srv
+}
+
+func (s *Server) Create(ctx context.Context, req *pb.CreateActorRequest) (*pb.CreateActorResponse, error) {
+	input := domain.ActorInput{
+		Name:       req.GetName(),
+		Surname:    req.GetSurname(),
+		Sex:        req.GetSex(),
+		BirthYear:  int(req.GetBirthYear()),
+		BirthPlace: req.GetBirthPlace(),
+		RestYear:   intPtr(req.RestYear),
+		Language:   req.Language,
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, toStatus(err)
+	}
+
+	id, err := s.actorsService.Create(ctx, input)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.CreateActorResponse{Id: id}, nil
+}
+
+func (s *Server) Get(ctx context.Context, req *pb.GetActorRequest) (*pb.Actor, error) {
+	actor, err := s.actorsService.GetByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toPbActor(actor), nil
+}
+
+// List streams every actor matching the filters, reading them from the
+// repository page by page.
+func (s *Server) List(req *pb.ListActorsRequest, stream pb.ActorsService_ListServer) error {
+	opts := domain.ActorsListOptions{
+		Sex:           req.Sex,
+		BirthYearFrom: intPtr(req.BirthYearFrom),
+		BirthYearTo:   intPtr(req.BirthYearTo),
+		BirthPlace:    req.BirthPlace,
+		Language:      req.Language,
+		Retired:       req.Retired,
+		Sort:          req.GetSort(),
+		Order:         req.GetOrder(),
+		Limit:         domain.MaxActorsLimit,
+	}
+
+	if err := opts.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	for {
+		page, err := s.actorsService.GetAllActors(stream.Context(), opts)
+		if err != nil {
+			return toStatus(err)
+		}
+
+		for _, actor := range page.Actors {
+			if err := stream.Send(toPbActor(actor)); err != nil {
+				return err
+			}
+		}
+
+		if page.NextCursor == "" {
+			return nil
+		}
+
+		opts.Cursor = page.NextCursor
+	}
+}
+
+func (s *Server) Update(ctx context.Context, req *pb.UpdateActorRequest) (*emptypb.Empty, error) {
+	info := domain.UpdateActorInfo{
+		Name:     req.Name,
+		Surname:  req.Surname,
+		Sex:      req.Sex,
+		RestYear: intPtr(req.RestYear),
+		Language: req.Language,
+	}
+
+	if err := info.Validate(); err != nil {
+		return nil, toStatus(err)
+	}
+
+	if _, err := s.actorsService.Update(ctx, req.GetId(), domain.AnyVersion, info); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) Delete(ctx context.Context, req *pb.DeleteActorRequest) (*emptypb.Empty, error) {
+	if err := s.actorsService.Delete(ctx, req.GetId(), domain.AnyVersion); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func toPbActor(actor domain.Actor) *pb.Actor {
+	res := &pb.Actor{
+		Id:         actor.ID,
+		Name:       actor.Name,
+		Surname:    actor.Surname,
+		Sex:        actor.Sex,
+		BirthYear:  int32(actor.BirthYear),
+		BirthPlace: actor.BirthPlace,
+		Language:   actor.Language,
+	}
+
+	if actor.RestYear != nil {
+		restYear := int32(*actor.RestYear)
+		res.RestYear = &restYear
+	}
+
+	return res
+}
+
+func intPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+
+	i := int(*v)
+
+	return &i
+}
+
+func toStatus(err error) error {
+	var validationErr *domain.ValidationError
+
+	switch {
+	case errors.As(err, &validationErr):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrActorNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrActorExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrActorModified):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrInvalidCursor):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, "internal error")
+	}
+}
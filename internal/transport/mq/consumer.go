@@ -0,0 +1,304 @@
+package mq
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/config"
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	amqp "github.com/rabbitmq/amqp091-go"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	importConsumerTag = "actors-import"
+	defaultWorkers    = 4
+
+	// errorReasonHeader tells why a message was dead-lettered by the consumer.
+	errorReasonHeader = "x-error-reason"
+)
+
+var ErrEmptyImport = errors.New("no actors in message")
+
+type ActorsImporter interface {
+	Import(ctx context.Context, actors []domain.ActorInput) error
+}
+
+// ActorsConsumer imports actors from a queue. A message holds either an actor
+// or an array of them, imported at once. Invalid messages are moved to the
+// dead-letter queue with the reason in the x-error-reason header and acked
+// once the broker confirms it has them; a message failing to import is
+// requeued once and dead-lettered by the broker if it fails again.
+type ActorsConsumer struct {
+	url            string
+	cfg            config.MQImport
+	importer       ActorsImporter
+	confirmTimeout time.Duration
+}
+
+func NewActorsConsumer(cfg *config.Config, importer ActorsImporter) *ActorsConsumer {
+	confirmTimeout := cfg.MQ.ConfirmTimeout
+	if confirmTimeout <= 0 {
+		confirmTimeout = defaultConfirmTimeout
+	}
+
+	return &ActorsConsumer{
+		url:            cfg.MQ.URL,
+		cfg:            cfg.MQ.Import,
+		importer:       importer,
+		confirmTimeout: confirmTimeout,
+	}
+}
+
+// Run consumes messages until ctx is done, reconnecting with a backoff when
+// the connection is lost. The imports running when ctx is done are cancelled
+// and their messages requeued before Run returns.
+func (c *ActorsConsumer) Run(ctx context.Context) {
+	delay := minReconnectDelay
+
+	for {
+		connected, err := c.consume(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if connected {
+			delay = minReconnectDelay
+		}
+
+		log.WithField("actors import", "consumer stopped, restarting in "+delay.String()).Error(err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		if delay *= 2; delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+func (c *ActorsConsumer) consume(ctx context.Context) (bool, error) {
+	conn, err := amqp.Dial(c.url)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return false, err
+	}
+
+	if err := c.declare(ch); err != nil {
+		return false, err
+	}
+
+	// dead-lettered messages are acked only after the broker confirms them
+	if err := ch.Confirm(false); err != nil {
+		return false, err
+	}
+
+	workers := c.cfg.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+
+	if err := ch.Qos(workers, 0, false); err != nil {
+		return false, err
+	}
+
+	deliveries, err := ch.Consume(c.cfg.Queue, importConsumerTag, false, false, false, false, nil)
+	if err != nil {
+		return false, err
+	}
+
+	closed := ch.NotifyClose(make(chan *amqp.Error, 1))
+
+	log.Info("ACTORS IMPORT CONSUMER STARTED")
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for d := range deliveries {
+				c.handle(ctx, ch, d)
+			}
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+		// deliveries is closed once the broker stops sending messages
+		if err := ch.Cancel(importConsumerTag, false); err != nil {
+			log.WithField("actors import", "failed to cancel consumer").Error(err)
+		}
+
+		wg.Wait()
+
+		return true, nil
+	case amqpErr, ok := <-closed:
+		wg.Wait()
+
+		if ok && amqpErr != nil {
+			return true, amqpErr
+		}
+
+		return true, ErrNotConnected
+	}
+}
+
+func (c *ActorsConsumer) declare(ch *amqp.Channel) error {
+	args := amqp.Table{}
+
+	if dl := c.cfg.DeadLetter; dl.Exchange != "" {
+		if err := ch.ExchangeDeclare(dl.Exchange, amqp.ExchangeFanout, true, false, false, false, nil); err != nil {
+			return err
+		}
+
+		if _, err := ch.QueueDeclare(dl.Queue, true, false, false, false, nil); err != nil {
+			return err
+		}
+
+		if err := ch.QueueBind(dl.Queue, "", dl.Exchange, false, nil); err != nil {
+			return err
+		}
+
+		args["x-dead-letter-exchange"] = dl.Exchange
+	}
+
+	_, err := ch.QueueDeclare(c.cfg.Queue, true, false, false, false, args)
+
+	return err
+}
+
+func (c *ActorsConsumer) handle(ctx context.Context, ch *amqp.Channel, d amqp.Delivery) {
+	actors, err := decodeActors(d.Body)
+	if err != nil {
+		c.deadLetter(ctx, ch, d, err)
+
+		return
+	}
+
+	if err := c.importer.Import(ctx, actors); err != nil {
+		log.WithFields(log.Fields{
+			"actors import": "failed to import",
+			"redelivered":   d.Redelivered,
+		}).Error(err)
+
+		// an import cancelled by the shutdown isn't the message's fault
+		requeue := !d.Redelivered || ctx.Err() != nil
+
+		if err := d.Nack(false, requeue); err != nil {
+			log.WithField("actors import", "failed to nack").Error(err)
+		}
+
+		return
+	}
+
+	if err := d.Ack(false); err != nil {
+		log.WithField("actors import", "failed to ack").Error(err)
+	}
+}
+
+// deadLetter publishes the message to the dead-letter exchange with the reason
+// it was rejected, since the broker's own dead-lettering doesn't carry one.
+// The message is acked only once the copy is confirmed and requeued
+// otherwise. Without a dead-letter exchange the message is dropped.
+func (c *ActorsConsumer) deadLetter(ctx context.Context, ch *amqp.Channel, d amqp.Delivery, reason error) {
+	log.WithField("actors import", "invalid message").Error(reason)
+
+	if exchange := c.cfg.DeadLetter.Exchange; exchange != "" {
+		if err := c.publishDeadLetter(ctx, ch, exchange, d, reason); err != nil {
+			log.WithField("actors import", "failed to dead-letter").Error(err)
+
+			if err := d.Nack(false, true); err != nil {
+				log.WithField("actors import", "failed to nack").Error(err)
+			}
+
+			return
+		}
+	}
+
+	if err := d.Ack(false); err != nil {
+		log.WithField("actors import", "failed to ack").Error(err)
+	}
+}
+
+func (c *ActorsConsumer) publishDeadLetter(ctx context.Context, ch *amqp.Channel, exchange string, d amqp.Delivery, reason error) error {
+	headers := amqp.Table{}
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+
+	headers[errorReasonHeader] = reason.Error()
+
+	ctx, cancel := context.WithTimeout(ctx, c.confirmTimeout)
+	defer cancel()
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, c.cfg.Queue, false, false,
+		amqp.Publishing{
+			Headers:      headers,
+			ContentType:  d.ContentType,
+			DeliveryMode: amqp.Persistent,
+			MessageId:    d.MessageId,
+			Body:         d.Body,
+		})
+	if err != nil {
+		return err
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !acked {
+		return ErrNacked
+	}
+
+	return nil
+}
+
+// decodeActors decodes and validates an actor or an array of actors.
+func decodeActors(body []byte) ([]domain.ActorInput, error) {
+	body = bytes.TrimSpace(body)
+
+	var actors []domain.ActorInput
+
+	if len(body) > 0 && body[0] == '[' {
+		if err := json.Unmarshal(body, &actors); err != nil {
+			return nil, err
+		}
+	} else {
+		var actor domain.ActorInput
+		if err := json.Unmarshal(body, &actor); err != nil {
+			return nil, err
+		}
+
+		actors = append(actors, actor)
+	}
+
+	if len(actors) == 0 {
+		return nil, ErrEmptyImport
+	}
+
+	for i, actor := range actors {
+		if err := actor.Validate(); err != nil {
+			return nil, fmt.Errorf("actor %d: %w", i, err)
+		}
+	}
+
+	return actors, nil
+}
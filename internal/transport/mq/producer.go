@@ -3,51 +3,242 @@ package mq
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/config"
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
 	amqp "github.com/rabbitmq/amqp091-go"
 	log "github.com/sirupsen/logrus"
 )
 
+var (
+	ErrNotConnected = errors.New("not connected to rabbitmq")
+	ErrNacked       = errors.New("message was not confirmed by rabbitmq")
+)
+
+const (
+	defaultConfirmTimeout = 5 * time.Second
+	minReconnectDelay     = time.Second
+	maxReconnectDelay     = 30 * time.Second
+)
+
+// AuditPublisher publishes messages to an exchange in confirm mode: Publish
+// returns only after the broker has taken responsibility for the message.
+// When the connection is lost it reconnects with a backoff; publishing fails
+// meanwhile, the outbox keeps the messages until they are confirmed.
 type AuditPublisher struct {
-	amqpChan *amqp.Channel
-	qname    string
+	url            string
+	qname          string
+	topology       config.MQTopology
+	legacy         bool
+	confirmTimeout time.Duration
+
+	mu      sync.RWMutex
+	conn    *amqp.Connection
+	ch      *amqp.Channel
+	lastErr error
+
+	done chan struct{}
 }
 
-func CreateMQConnection(url string) (*amqp.Connection, error) {
-	return amqp.Dial(url)
+func NewAuditPublisher(cfg *config.Config) *AuditPublisher {
+	confirmTimeout := cfg.MQ.ConfirmTimeout
+	if confirmTimeout <= 0 {
+		confirmTimeout = defaultConfirmTimeout
+	}
+
+	ap := &AuditPublisher{
+		url:            cfg.MQ.URL,
+		qname:          cfg.MQ.Name,
+		topology:       cfg.MQ.Topology,
+		legacy:         cfg.Audit.Format == config.AuditFormatLegacy,
+		confirmTimeout: confirmTimeout,
+		lastErr:        ErrNotConnected,
+		done:           make(chan struct{}),
+	}
+
+	go ap.run()
+
+	return ap
 }
 
-func NewAuditPublisher(cfg *config.Config, mqConn *amqp.Connection) (*AuditPublisher, error) {
-	amqpChan, err := mqConn.Channel()
-	if err != nil {
-		return nil, errors.New("failed to open a channel")
+// Close stops reconnecting and closes the connection.
+func (ap *AuditPublisher) Close() {
+	close(ap.done)
+
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+
+	if ap.conn != nil {
+		if err := ap.conn.Close(); err != nil {
+			log.WithField("rabbitmq", "failed to close connection").Error(err)
+		}
 	}
 
-	return &AuditPublisher{amqpChan: amqpChan, qname: cfg.MQ.Name}, nil
+	ap.conn, ap.ch = nil, nil
+	ap.lastErr = ErrNotConnected
 }
 
-func (ap *AuditPublisher) CloseChan() {
-	if err := ap.amqpChan.Close(); err != nil {
-		log.WithField("rabbitmq", "failed to close chan")
+// Healthy returns nil if the publisher is connected and the reason it is not
+// otherwise.
+func (ap *AuditPublisher) Healthy() error {
+	ap.mu.RLock()
+	defer ap.mu.RUnlock()
+
+	if ap.ch == nil {
+		return fmt.Errorf("%w: %v", ErrNotConnected, ap.lastErr)
 	}
+
+	return nil
 }
 
-func (ap *AuditPublisher) Publish(ctx context.Context, body []byte) error {
-	err := ap.amqpChan.PublishWithContext(
+// Publish sends the event to the exchange with its routing key and waits for
+// the broker to confirm it. Without an exchange configured the event is sent
+// to the queue directly. While disconnected it fails with ErrNotConnected, so
+// the caller keeps the event and retries it.
+func (ap *AuditPublisher) Publish(ctx context.Context, event domain.Event) error {
+	ap.mu.RLock()
+	ch := ap.ch
+	ap.mu.RUnlock()
+
+	if ch == nil {
+		return ErrNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ap.confirmTimeout)
+	defer cancel()
+
+	routingKey := event.RoutingKey
+	if ap.topology.Exchange == "" {
+		routingKey = ap.qname
+	}
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(
 		ctx,
-		"",
-		ap.qname,
+		ap.topology.Exchange,
+		routingKey,
 		false,
 		false,
-		amqp.Publishing{
-			ContentType: "text/plain",
-			Body:        body,
-		},
+		toPublishing(event, ap.legacy),
 	)
 	if err != nil {
-		log.WithField("rabbitmq", "failed to publish log")
+		return err
 	}
 
-	return err
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !acked {
+		return ErrNacked
+	}
+
+	return nil
+}
+
+// run keeps the publisher connected until Close is called.
+func (ap *AuditPublisher) run() {
+	delay := minReconnectDelay
+
+	for {
+		closed, err := ap.connect()
+		if err != nil {
+			ap.setLastErr(err)
+			log.WithField("rabbitmq", "failed to connect, retrying in "+delay.String()).Error(err)
+
+			select {
+			case <-ap.done:
+				return
+			case <-time.After(delay):
+			}
+
+			if delay *= 2; delay > maxReconnectDelay {
+				delay = maxReconnectDelay
+			}
+
+			continue
+		}
+
+		delay = minReconnectDelay
+
+		log.Info("RABBITMQ CONNECTED")
+
+		select {
+		case <-ap.done:
+			return
+		case amqpErr, ok := <-closed:
+			err := ErrNotConnected
+			if ok && amqpErr != nil {
+				err = amqpErr
+			}
+
+			ap.disconnect(err)
+			log.WithField("rabbitmq", "connection lost").Error(err)
+		}
+	}
+}
+
+func (ap *AuditPublisher) connect() (chan *amqp.Error, error) {
+	conn, err := amqp.Dial(ap.url)
+	if err != nil {
+		return nil, err
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	if err := declareTopology(ch, ap.qname, ap.topology); err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	if err := ch.Confirm(false); err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	// the channel is closed along with the connection, so watching it is enough
+	closed := ch.NotifyClose(make(chan *amqp.Error, 1))
+
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+
+	select {
+	case <-ap.done:
+		conn.Close()
+
+		return nil, ErrNotConnected
+	default:
+	}
+
+	ap.conn, ap.ch, ap.lastErr = conn, ch, nil
+
+	return closed, nil
+}
+
+func (ap *AuditPublisher) disconnect(err error) {
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+
+	if ap.conn != nil && !ap.conn.IsClosed() {
+		ap.conn.Close()
+	}
+
+	ap.conn, ap.ch, ap.lastErr = nil, nil, err
+}
+
+func (ap *AuditPublisher) setLastErr(err error) {
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+
+	ap.lastErr = err
 }
@@ -3,13 +3,54 @@ package mq
 import (
 	"bytes"
 	"encoding/json"
+	"time"
 
-	audit "github.com/AngelicaNice/auditlog_mq/pkg/domain"
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	amqp "github.com/rabbitmq/amqp091-go"
 )
 
-func Serialize(log audit.LogItem) ([]byte, error) {
+// cloudEventsPrefix prefixes the event attributes in the message headers,
+// as the CloudEvents AMQP binding does for application properties.
+const cloudEventsPrefix = "cloudEvents_"
+
+func Serialize(log interface{}) ([]byte, error) {
 	var b bytes.Buffer
 	encoder := json.NewEncoder(&b)
 	err := encoder.Encode(log)
 	return b.Bytes(), err
 }
+
+// toPublishing encodes the event in the CloudEvents binary content mode: the
+// data is the body and the attributes are headers. In the legacy format only
+// the data is sent.
+func toPublishing(event domain.Event, legacy bool) amqp.Publishing {
+	if legacy {
+		return amqp.Publishing{
+			ContentType:  "text/plain",
+			DeliveryMode: amqp.Persistent,
+			Body:         event.Data,
+		}
+	}
+
+	headers := amqp.Table{
+		cloudEventsPrefix + "specversion": event.SpecVersion,
+		cloudEventsPrefix + "id":          event.ID,
+		cloudEventsPrefix + "source":      event.Source,
+		cloudEventsPrefix + "type":        event.Type,
+		cloudEventsPrefix + "time":        event.Time.UTC().Format(time.RFC3339Nano),
+	}
+
+	if event.Subject != "" {
+		headers[cloudEventsPrefix+"subject"] = event.Subject
+	}
+
+	return amqp.Publishing{
+		Headers:      headers,
+		ContentType:  event.DataContentType,
+		DeliveryMode: amqp.Persistent,
+		MessageId:    event.ID,
+		Timestamp:    event.Time,
+		Type:         event.Type,
+		Body:         event.Data,
+	}
+}
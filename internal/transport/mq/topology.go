@@ -0,0 +1,64 @@
+package mq
+
+import (
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/config"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// declareTopology declares the queue, the exchange it is bound to and the
+// dead-letter exchange and queue. Declaring is idempotent as long as the
+// arguments match the existing ones, otherwise the broker closes the channel.
+func declareTopology(ch *amqp.Channel, queue string, t config.MQTopology) error {
+	args := amqp.Table{}
+
+	if t.DeadLetter.Exchange != "" {
+		if err := ch.ExchangeDeclare(t.DeadLetter.Exchange, amqp.ExchangeFanout, true, false, false, false, nil); err != nil {
+			return err
+		}
+
+		args["x-dead-letter-exchange"] = t.DeadLetter.Exchange
+
+		if t.DeadLetter.Queue != "" {
+			dlqArgs := amqp.Table{}
+			if t.DeadLetter.TTL > 0 {
+				dlqArgs["x-message-ttl"] = t.DeadLetter.TTL.Milliseconds()
+			}
+
+			if _, err := ch.QueueDeclare(t.DeadLetter.Queue, true, false, false, false, dlqArgs); err != nil {
+				return err
+			}
+
+			if err := ch.QueueBind(t.DeadLetter.Queue, "", t.DeadLetter.Exchange, false, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	if t.MessageTTL > 0 {
+		args["x-message-ttl"] = t.MessageTTL.Milliseconds()
+	}
+
+	if _, err := ch.QueueDeclare(queue, true, false, false, false, args); err != nil {
+		return err
+	}
+
+	if t.Exchange == "" {
+		return nil
+	}
+
+	kind := t.ExchangeType
+	if kind == "" {
+		kind = amqp.ExchangeTopic
+	}
+
+	if err := ch.ExchangeDeclare(t.Exchange, kind, true, false, false, false, nil); err != nil {
+		return err
+	}
+
+	bindingKey := t.BindingKey
+	if bindingKey == "" {
+		bindingKey = "#"
+	}
+
+	return ch.QueueBind(queue, bindingKey, t.Exchange, false, nil)
+}
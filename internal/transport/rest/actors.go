@@ -1,14 +1,15 @@
 package rest
 
 import (
-	"context"
+	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
 	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	"github.com/AngelicaNice/HollywoodStarsCRUD/pkg/jsonpatch"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 )
@@ -17,34 +18,32 @@ import (
 //
 //	@Summary		Add actor
 //	@Security 		ApiKeyAuth
-//	@Description	add actor info
+//	@Description	add actor info, fails with 409 if an actor with the same name, surname and birth year exists
 //	@Tags			actor
 //	@Accept			json
 //	@Produce		json
 //	@Param			input body domain.ActorInput true "actor's info"
 //	@Success		201	{integer} integer 1
-//	@Failure		400,404,500 {integer} integer 0
-//	@Router			/actors [post]
+//	@Failure		400,404,409,422,500 {object} problem
+//	@Router			/api/v1/actors [post]
 func (h *Handler) AddActor(c *gin.Context) {
 	var actor domain.ActorInput
 
 	decoder := json.NewDecoder(c.Request.Body)
 	if err := decoder.Decode(&actor); err != nil {
-		log.WithFields(log.Fields{
-			"handler": "AddActor",
-			"issue":   "failed unmarshalling request body",
-		}).Error(err)
-		c.Writer.WriteHeader(http.StatusBadRequest)
+		handleBadRequest(c, "AddActor", "failed unmarshalling request body", err)
 
 		return
 	}
 
-	if _, err := h.actorsService.Create(context.TODO(), actor); err != nil {
-		log.WithFields(log.Fields{
-			"handler": "AddActor",
-			"issue":   "internal error",
-		}).Error(err)
-		c.Writer.WriteHeader(http.StatusInternalServerError)
+	if err := actor.Validate(); err != nil {
+		handleError(c, "AddActor", err)
+
+		return
+	}
+
+	if _, err := h.actorsService.Create(c.Request.Context(), actor); err != nil {
+		handleError(c, "AddActor", err)
 
 		return
 	}
@@ -56,38 +55,108 @@ func (h *Handler) AddActor(c *gin.Context) {
 //
 //	@Summary		Get all actors
 //	@Security 		ApiKeyAuth
-//	@Description	get all actors info
+//	@Description	get actors info filtered, sorted and paginated by cursor
 //	@Tags			actor
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{integer} integer 1
-//	@Failure		400,404,500 {integer} integer 0
-//	@Router			/actors [get]
+//	@Param			sex				query	string	false	"filter by sex"
+//	@Param			birth_year_from	query	int		false	"minimal birth year"
+//	@Param			birth_year_to	query	int		false	"maximal birth year"
+//	@Param			birth_place		query	string	false	"filter by birth place"
+//	@Param			language		query	string	false	"filter by language"
+//	@Param			retired			query	bool	false	"retired (rest_year is set) or active actors"
+//	@Param			sort			query	string	false	"sort field"	Enums(id, name, surname, birth_year)
+//	@Param			order			query	string	false	"sort order"	Enums(asc, desc)
+//	@Param			limit			query	int		false	"page size"		minimum(1)	maximum(100)
+//	@Param			cursor			query	string	false	"next_cursor from the previous page, valid only with the same sort and order"
+//	@Success		200	{object} domain.ActorsPage
+//	@Failure		400,404,422,500 {object} problem
+//	@Router			/api/v1/actors [get]
 func (h *Handler) GetAllActors(c *gin.Context) {
-	actors, err := h.actorsService.GetAllActors(context.TODO())
+	var opts domain.ActorsListOptions
+
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		handleBadRequest(c, "GetAllActors", "failed reading query params", err)
+
+		return
+	}
+
+	if err := opts.Validate(); err != nil {
+		handleError(c, "GetAllActors", err)
+
+		return
+	}
+
+	page, err := h.actorsService.GetAllActors(c.Request.Context(), opts)
 	if err != nil {
+		handleError(c, "GetAllActors", err)
+
+		return
+	}
+
+	c.Writer.Header().Add("Content-Type", "application/json")
+
+	encoder := json.NewEncoder(c.Writer)
+
+	if err := encoder.Encode(&page); err != nil {
 		log.WithFields(log.Fields{
 			"handler": "GetAllActors",
-			"issue":   "internal error",
+			"issue":   "failed marshaling response body",
 		}).Error(err)
 		c.Writer.WriteHeader(http.StatusInternalServerError)
 
 		return
 	}
+}
+
+// Auth godoc
+//
+//	@Summary		Search actors
+//	@Security 		ApiKeyAuth
+//	@Description	fuzzy full-text search over name, surname and birth place ranked by relevance
+//	@Tags			actor
+//	@Accept			json
+//	@Produce		json
+//	@Param			q		query	string	true	"search query"
+//	@Param			limit	query	int		false	"max results"	minimum(1)	maximum(100)
+//	@Success		200	{array} domain.ActorSearchResult
+//	@Failure		400,404,422,500 {object} problem
+//	@Router			/api/v1/actors/search [get]
+func (h *Handler) SearchActors(c *gin.Context) {
+	var input domain.ActorsSearchInput
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		handleBadRequest(c, "SearchActors", "failed reading query params", err)
+
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		handleError(c, "SearchActors", err)
+
+		return
+	}
+
+	results, err := h.actorsService.Search(c.Request.Context(), input)
+	if err != nil {
+		handleError(c, "SearchActors", err)
+
+		return
+	}
+
+	c.Writer.Header().Add("Content-Type", "application/json")
 
 	encoder := json.NewEncoder(c.Writer)
 
-	if err := encoder.Encode(&actors); err != nil {
+	if err := encoder.Encode(&results); err != nil {
 		log.WithFields(log.Fields{
-			"handler": "GetAllActors",
+			"handler": "SearchActors",
 			"issue":   "failed marshaling response body",
 		}).Error(err)
 		c.Writer.WriteHeader(http.StatusInternalServerError)
 
 		return
 	}
-
-	c.Writer.Header().Add("Content-Type", "application/json")
 }
 
 // Auth godoc
@@ -98,110 +167,201 @@ func (h *Handler) GetAllActors(c *gin.Context) {
 //	@Tags			actor
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	query	int	false 	"int valid"	minimum(1)
-//	@Success		200	{integer} integer 1
-//	@Failure		400,404,500 {integer} integer 0
-//	@Router			/actors/id [get]
+//	@Param			id	path	int	true	"actor id"	minimum(1)
+//	@Param			If-None-Match	header	string	false	"ETag of the cached actor"
+//	@Success		200	{object} domain.Actor
+//	@Header			200	{string}	ETag	"version of the actor"
+//	@Success		304
+//	@Failure		400,404,500 {object} problem
+//	@Router			/api/v1/actors/{id} [get]
 func (h *Handler) GetActor(c *gin.Context) {
-	id, err := getIdFromRequest(c.Request)
+	id, err := getIdFromParam(c, "id")
 	if err != nil {
-		log.WithFields(log.Fields{
-			"handler": "GetActor",
-			"issue":   "failed reading request param",
-		}).Error(err)
-		c.Writer.WriteHeader(http.StatusBadRequest)
+		handleBadRequest(c, "GetActor", "failed reading request param", err)
 
 		return
 	}
 
-	actor, err := h.actorsService.GetByID(context.TODO(), id)
+	actor, err := h.actorsService.GetByID(c.Request.Context(), id)
 	if err != nil {
-		if errors.Is(err, domain.ErrActorNotFound) {
-			issue := fmt.Sprintf("actor with id=%d not found", id)
-			log.WithFields(log.Fields{
-				"handler": "GetActor",
-				"issue":   issue,
-			}).Error(err)
-			c.Writer.WriteHeader(http.StatusBadRequest)
+		handleError(c, "GetActor", err)
 
-			return
-		}
+		return
+	}
 
-		c.Writer.WriteHeader(http.StatusInternalServerError)
+	etag := actorETag(actor.Version)
+	c.Header("ETag", etag)
+
+	if notModified(c, etag) {
+		writeNotModified(c)
 
 		return
 	}
 
-	encoder := json.NewEncoder(c.Writer)
+	writeJSON(c, "GetActor", &actor)
+}
 
-	if err := encoder.Encode(&actor); err != nil {
-		c.Writer.WriteHeader(http.StatusInternalServerError)
+// UpdateActor changes the fields given in the body and leaves the others, it
+// serves the deprecated PUT /actors/id?id=.
+func (h *Handler) UpdateActor(c *gin.Context) {
+	id, err := getIdFromParam(c, "id")
+	if err != nil {
+		handleBadRequest(c, "UpdateActor", "failed reading request param", err)
 
 		return
 	}
 
-	c.Writer.Header().Add("Content-Type", "application/json")
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		handleError(c, "UpdateActor", err)
+
+		return
+	}
+
+	var src domain.UpdateActorInfo
+
+	decoder := json.NewDecoder(c.Request.Body)
+	if err := decoder.Decode(&src); err != nil {
+		handleBadRequest(c, "UpdateActor", "bad request", err)
+
+		return
+	}
+
+	if err := src.Validate(); err != nil {
+		handleError(c, "UpdateActor", err)
+
+		return
+	}
+
+	actor, err := h.actorsService.Update(c.Request.Context(), id, version, src)
+	if err != nil {
+		handleError(c, "UpdateActor", err)
+
+		return
+	}
+
+	c.Header("ETag", actorETag(actor.Version))
+	c.Writer.WriteHeader(http.StatusOK)
 }
 
 // Auth godoc
 //
-//	@Summary		Update actor by id
+//	@Summary		Replace actor by id
 //	@Security 		ApiKeyAuth
-//	@Description	Update actor info by id
+//	@Description	Replace actor info by id, rest_year and language left out are cleared
 //	@Tags			actor
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	query	int	false 	"int valid"	minimum(1)
-//	@Param			input body domain.UpdateActorInfo true "new actor's info"
+//	@Param			id	path	int	true	"actor id"	minimum(1)
+//	@Param			If-Match	header	string	true	"ETag of the actor, * for any version"
+//	@Param			input body domain.ActorInput true "new actor's info"
 //	@Success		200	{integer} integer 1
-//	@Failure		400,404,500 {integer} integer 0
-//	@Router			/actors/id [put]
-func (h *Handler) UpdateActor(c *gin.Context) {
-	id, err := getIdFromRequest(c.Request)
+//	@Header			200	{string}	ETag	"new version of the actor"
+//	@Failure		400,404,409,412,422,428,500 {object} problem
+//	@Router			/api/v1/actors/{id} [put]
+func (h *Handler) ReplaceActor(c *gin.Context) {
+	id, err := getIdFromParam(c, "id")
 	if err != nil {
-		log.WithFields(log.Fields{
-			"handler": "UpdateActor",
-			"issue":   "failed reading request param",
-		}).Error(err)
-		c.Writer.WriteHeader(http.StatusBadRequest)
+		handleBadRequest(c, "ReplaceActor", "failed reading request param", err)
 
 		return
 	}
 
-	var src domain.UpdateActorInfo
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		handleError(c, "ReplaceActor", err)
+
+		return
+	}
+
+	var actor domain.ActorInput
 
 	decoder := json.NewDecoder(c.Request.Body)
-	if err := decoder.Decode(&src); err != nil {
-		log.WithFields(log.Fields{
-			"handler": "UpdateActor",
-			"issue":   "bad request",
-		}).Error(err)
-		c.Writer.WriteHeader(http.StatusBadRequest)
+	if err := decoder.Decode(&actor); err != nil {
+		handleBadRequest(c, "ReplaceActor", "failed unmarshalling request body", err)
 
 		return
 	}
 
-	if err = h.actorsService.Update(context.TODO(), id, src); err != nil {
-		if errors.Is(err, domain.ErrActorNotFound) {
-			issue := fmt.Sprintf("actor with id=%d not found", id)
-			log.WithFields(log.Fields{
-				"handler": "UpdateActor",
-				"issue":   issue,
-			}).Error(err)
-			c.Writer.WriteHeader(http.StatusBadRequest)
+	if err := actor.Validate(); err != nil {
+		handleError(c, "ReplaceActor", err)
 
-			return
-		}
+		return
+	}
 
-		log.WithFields(log.Fields{
-			"handler": "UpdateActor",
-			"issue":   "internal error",
-		}).Error(err)
-		c.Writer.WriteHeader(http.StatusInternalServerError)
+	replaced, err := h.actorsService.Replace(c.Request.Context(), id, version, actor)
+	if err != nil {
+		handleError(c, "ReplaceActor", err)
 
 		return
 	}
 
+	c.Header("ETag", actorETag(replaced.Version))
+	c.Writer.WriteHeader(http.StatusOK)
+}
+
+// Auth godoc
+//
+//	@Summary		Patch actor by id
+//	@Security 		ApiKeyAuth
+//	@Description	Change actor info by id with a JSON Merge Patch (RFC 7396, also for application/json)
+//	@Description	or a JSON Patch (RFC 6902), null or remove clears rest_year and language
+//	@Tags			actor
+//	@Accept			application/merge-patch+json,application/json-patch+json,json
+//	@Produce		json
+//	@Param			id	path	int	true	"actor id"	minimum(1)
+//	@Param			If-Match	header	string	true	"ETag of the actor, * for any version"
+//	@Param			input body object true "patch"
+//	@Success		200	{integer} integer 1
+//	@Header			200	{string}	ETag	"new version of the actor"
+//	@Failure		400,404,409,412,415,422,428,500 {object} problem
+//	@Router			/api/v1/actors/{id} [patch]
+func (h *Handler) PatchActor(c *gin.Context) {
+	id, err := getIdFromParam(c, "id")
+	if err != nil {
+		handleBadRequest(c, "PatchActor", "failed reading request param", err)
+
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		handleError(c, "PatchActor", err)
+
+		return
+	}
+
+	var apply func(doc, patch []byte) ([]byte, error)
+
+	switch c.ContentType() {
+	case "application/merge-patch+json", "application/json":
+		apply = jsonpatch.MergePatch
+	case "application/json-patch+json":
+		apply = jsonpatch.Apply
+	default:
+		handleError(c, "PatchActor", errUnsupportedPatch)
+
+		return
+	}
+
+	patch, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		handleBadRequest(c, "PatchActor", "failed reading request body", err)
+
+		return
+	}
+
+	patched, err := h.actorsService.Patch(c.Request.Context(), id, version,
+		func(actor domain.Actor) (domain.ActorInput, error) {
+			return patchActor(actor, patch, apply)
+		})
+	if err != nil {
+		handleError(c, "PatchActor", err)
+
+		return
+	}
+
+	c.Header("ETag", actorETag(patched.Version))
 	c.Writer.WriteHeader(http.StatusOK)
 }
 
@@ -209,44 +369,33 @@ func (h *Handler) UpdateActor(c *gin.Context) {
 //
 //	@Summary		Delete actor by id
 //	@Security 		ApiKeyAuth
-//	@Description	Delete actor info by id
+//	@Description	Move actor to the trash by id, it can be restored until it's purged
 //	@Tags			actor
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	query	int	false 	"int valid"	minimum(1)
+//	@Param			id	path	int	true	"actor id"	minimum(1)
+//	@Param			If-Match	header	string	true	"ETag of the actor, * for any version"
 //	@Success		200	{integer} integer 1
-//	@Failure		400,404,500 {integer} integer 0
-//	@Router			/actors/id [delete]
+//	@Failure		400,404,412,428,500 {object} problem
+//	@Router			/api/v1/actors/{id} [delete]
 func (h *Handler) DeleteActor(c *gin.Context) {
-	id, err := getIdFromRequest(c.Request)
+	id, err := getIdFromParam(c, "id")
 	if err != nil {
-		log.WithFields(log.Fields{
-			"handler": "DeleteActor",
-			"issue":   "failed reading request param",
-		}).Error(err)
-		c.Writer.WriteHeader(http.StatusBadRequest)
+		handleBadRequest(c, "DeleteActor", "failed reading request param", err)
 
 		return
 	}
 
-	err = h.actorsService.Delete(context.TODO(), id)
+	version, err := ifMatchVersion(c)
 	if err != nil {
-		if errors.Is(err, domain.ErrActorNotFound) {
-			issue := fmt.Sprintf("actor with id=%d not found", id)
-			log.WithFields(log.Fields{
-				"handler": "DeleteActor",
-				"issue":   issue,
-			}).Error(err)
-			c.Writer.WriteHeader(http.StatusBadRequest)
+		handleError(c, "DeleteActor", err)
 
-			return
-		}
+		return
+	}
 
-		log.WithFields(log.Fields{
-			"handler": "DeleteActor",
-			"issue":   "internal error",
-		}).Error(err)
-		c.Writer.WriteHeader(http.StatusInternalServerError)
+	err = h.actorsService.Delete(c.Request.Context(), id, version)
+	if err != nil {
+		handleError(c, "DeleteActor", err)
 
 		return
 	}
@@ -254,18 +403,70 @@ func (h *Handler) DeleteActor(c *gin.Context) {
 	c.Writer.WriteHeader(http.StatusOK)
 }
 
-func getIdFromRequest(r *http.Request) (int64, error) {
-	param := r.URL.RawQuery
-	param = param[3:]
+// Auth godoc
+//
+//	@Summary		Restore actor by id
+//	@Security 		ApiKeyAuth
+//	@Description	Take actor out of the trash, admins only
+//	@Tags			actor
+//	@Produce		json
+//	@Param			id	path	int	true	"actor id"	minimum(1)
+//	@Success		200	{object} domain.Actor
+//	@Header			200	{string}	ETag	"version of the actor"
+//	@Failure		400,401,403,404,409,500 {object} problem
+//	@Router			/api/v1/actors/{id}/restore [post]
+func (h *Handler) RestoreActor(c *gin.Context) {
+	id, err := getIdFromParam(c, "id")
+	if err != nil {
+		handleBadRequest(c, "RestoreActor", "failed reading request param", err)
+
+		return
+	}
+
+	actor, err := h.actorsService.Restore(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, "RestoreActor", err)
+
+		return
+	}
+
+	c.Header("ETag", actorETag(actor.Version))
+	writeJSON(c, "RestoreActor", &actor)
+}
 
-	id, err := strconv.ParseInt(param, 10, 64)
+func getIdFromParam(c *gin.Context, name string) (int64, error) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
 	if err != nil {
 		return 0, err
 	}
 
-	if id == 0 {
-		return 0, errors.New("actor id can't be 0")
+	if id <= 0 {
+		return 0, fmt.Errorf("%s must be positive", name)
 	}
 
 	return id, nil
 }
+
+// patchActor applies patch to the JSON document of the actor, without the id,
+// and validates the result.
+func patchActor(actor domain.Actor, patch []byte, apply func(doc, patch []byte) ([]byte, error)) (domain.ActorInput, error) {
+	doc, err := json.Marshal(actor.Input())
+	if err != nil {
+		return domain.ActorInput{}, err
+	}
+
+	if doc, err = apply(doc, patch); err != nil {
+		return domain.ActorInput{}, err
+	}
+
+	var patched domain.ActorInput
+
+	decoder := json.NewDecoder(bytes.NewReader(doc))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&patched); err != nil {
+		return domain.ActorInput{}, fmt.Errorf("%w: %s", errPatchedActor, err)
+	}
+
+	return patched, patched.ValidateChanged(actor.Input())
+}
@@ -0,0 +1,163 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// patchedActors is the Actors service of the PATCH tests, it runs the patch
+// function of the handler against a stored actor and keeps the result.
+type patchedActors struct {
+	Actors
+
+	actor   domain.Actor
+	patched domain.ActorInput
+}
+
+func (a *patchedActors) Patch(_ context.Context, _, _ int64, patch func(actor domain.Actor) (domain.ActorInput, error)) (domain.Actor, error) {
+	input, err := patch(a.actor)
+	if err != nil {
+		return domain.Actor{}, err
+	}
+
+	a.patched = input
+
+	return domain.Actor{ID: a.actor.ID, Version: a.actor.Version + 1}, nil
+}
+
+func TestPatchActorContentTypes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	restYear, language := 2020, "en"
+	stored := domain.Actor{
+		ID:         1,
+		Name:       "Keanu",
+		Surname:    "Reeves",
+		Sex:        "male",
+		BirthYear:  1964,
+		BirthPlace: "Beirut",
+		RestYear:   &restYear,
+		Language:   &language,
+		Version:    3,
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+		code        string
+		check       func(t *testing.T, patched domain.ActorInput)
+	}{
+		{
+			name:        "merge patch clears with null",
+			contentType: "application/merge-patch+json",
+			body:        `{"language":null,"rest_year":null}`,
+			status:      http.StatusOK,
+			check: func(t *testing.T, patched domain.ActorInput) {
+				if patched.Language != nil || patched.RestYear != nil || patched.Name != "Keanu" {
+					t.Errorf("patched = %+v, want language and rest_year cleared only", patched)
+				}
+			},
+		},
+		{
+			name:        "plain json is a merge patch",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"name":"John"}`,
+			status:      http.StatusOK,
+			check: func(t *testing.T, patched domain.ActorInput) {
+				if patched.Name != "John" || patched.Language == nil {
+					t.Errorf("patched = %+v, want only the name changed", patched)
+				}
+			},
+		},
+		{
+			name:        "json patch clears with null",
+			contentType: "application/json-patch+json",
+			body:        `[{"op":"test","path":"/name","value":"Keanu"},{"op":"replace","path":"/language","value":null},{"op":"remove","path":"/rest_year"}]`,
+			status:      http.StatusOK,
+			check: func(t *testing.T, patched domain.ActorInput) {
+				if patched.Language != nil || patched.RestYear != nil || patched.Name != "Keanu" {
+					t.Errorf("patched = %+v, want language and rest_year cleared only", patched)
+				}
+			},
+		},
+		{
+			name:        "json patch sent as a merge patch",
+			contentType: "application/merge-patch+json",
+			body:        `[{"op":"replace","path":"/name","value":"John"}]`,
+			status:      http.StatusUnprocessableEntity,
+			code:        codeInvalidPatchedActor,
+		},
+		{
+			name:        "failed json patch test",
+			contentType: "application/json-patch+json",
+			body:        `[{"op":"test","path":"/name","value":"John"}]`,
+			status:      http.StatusConflict,
+			code:        codePatchTestFailed,
+		},
+		{
+			name:        "malformed json patch",
+			contentType: "application/json-patch+json",
+			body:        `{"name":"John"}`,
+			status:      http.StatusBadRequest,
+			code:        codeInvalidPatch,
+		},
+		{
+			name:        "invalid result",
+			contentType: "application/merge-patch+json",
+			body:        `{"rest_year":1900}`,
+			status:      http.StatusUnprocessableEntity,
+			code:        codeValidationFailed,
+		},
+		{
+			name:        "unsupported content type",
+			contentType: "text/plain",
+			body:        `{"name":"John"}`,
+			status:      http.StatusUnsupportedMediaType,
+			code:        codeUnsupportedMedia,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actors := &patchedActors{actor: stored}
+
+			router := gin.New()
+			router.PATCH("/actors/:id", NewHandler(actors, nil, nil, nil, nil).PatchActor)
+
+			req := httptest.NewRequest(http.MethodPatch, "/actors/1", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			req.Header.Set("If-Match", actorETag(stored.Version))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+
+			if tt.code != "" {
+				var p problem
+				if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil || p.Code != tt.code {
+					t.Errorf("problem = %s, want code %q", w.Body, tt.code)
+				}
+			}
+
+			if tt.check != nil {
+				if etag := w.Header().Get("ETag"); etag != actorETag(stored.Version+1) {
+					t.Errorf("ETag = %q, want %q", etag, actorETag(stored.Version+1))
+				}
+
+				tt.check(t, actors.patched)
+			}
+		})
+	}
+}
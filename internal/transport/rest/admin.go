@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// Auth godoc
+//
+//	@Summary		Change user's role
+//	@Security 		ApiKeyAuth
+//	@Description	set viewer, editor or admin role of the user, admins only
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	int	true	"user id"	minimum(1)
+//	@Param			input body domain.UpdateRoleInput true "new role"
+//	@Success		200	{integer} integer 1
+//	@Failure		400,401,403,404,422,500 {object} problem
+//	@Router			/api/v1/admin/users/{id}/role [put]
+func (h *Handler) UpdateUserRole(c *gin.Context) {
+	id, err := getIdFromParam(c, "id")
+	if err != nil {
+		handleBadRequest(c, "UpdateUserRole", "failed reading request param", err)
+
+		return
+	}
+
+	var input domain.UpdateRoleInput
+
+	decoder := json.NewDecoder(c.Request.Body)
+	if err := decoder.Decode(&input); err != nil {
+		handleBadRequest(c, "UpdateUserRole", "failed unmarshalling request body", err)
+
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		handleError(c, "UpdateUserRole", err)
+
+		return
+	}
+
+	if err := h.usersService.UpdateRole(c.Request.Context(), id, input.Role); err != nil {
+		handleError(c, "UpdateUserRole", err)
+
+		return
+	}
+
+	c.Writer.WriteHeader(http.StatusOK)
+}
+
+// Auth godoc
+//
+//	@Summary		Get actors in the trash
+//	@Security 		ApiKeyAuth
+//	@Description	get deleted actors that haven't been purged yet, the most recently deleted first, admins only
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{array} domain.DeletedActor
+//	@Failure		401,403,500 {object} problem
+//	@Router			/api/v1/admin/trash/actors [get]
+func (h *Handler) GetTrashedActors(c *gin.Context) {
+	actors, err := h.actorsService.GetDeleted(c.Request.Context())
+	if err != nil {
+		handleError(c, "GetTrashedActors", err)
+
+		return
+	}
+
+	writeJSON(c, "GetTrashedActors", &actors)
+}
+
+// Auth godoc
+//
+//	@Summary		Purge actor by id
+//	@Security 		ApiKeyAuth
+//	@Description	permanently delete an actor in the trash with its roles and followers, admins only
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path	int	true	"actor id"	minimum(1)
+//	@Success		200	{integer} integer 1
+//	@Failure		400,401,403,404,500 {object} problem
+//	@Router			/api/v1/admin/trash/actors/{id} [delete]
+func (h *Handler) PurgeActor(c *gin.Context) {
+	id, err := getIdFromParam(c, "id")
+	if err != nil {
+		handleBadRequest(c, "PurgeActor", "failed reading request param", err)
+
+		return
+	}
+
+	if err := h.actorsService.Purge(c.Request.Context(), id); err != nil {
+		handleError(c, "PurgeActor", err)
+
+		return
+	}
+
+	c.Writer.WriteHeader(http.StatusOK)
+}
@@ -3,13 +3,11 @@ package rest
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
 	"github.com/gin-gonic/gin"
-	log "github.com/sirupsen/logrus"
 )
 
 // Auth godoc
@@ -21,38 +19,26 @@ import (
 //	@Produce		json
 //	@Param			input body domain.SignUpInput true "user's info"
 //	@Success		201	{integer} integer 1
-//	@Failure		400,404,500 {integer} integer 0
-//	@Router			/auth/sign-up [post]
+//	@Failure		400,404,422,500 {object} problem
+//	@Router			/api/v1/auth/sign-up [post]
 func (h *Handler) SignUp(c *gin.Context) {
 	var user domain.SignUpInput
 
 	decoder := json.NewDecoder(c.Request.Body)
 	if err := decoder.Decode(&user); err != nil {
-		log.WithFields(log.Fields{
-			"handler": "SignUp",
-			"issue":   "failed unmarshalling request body",
-		}).Error(err)
-		c.Writer.WriteHeader(http.StatusBadRequest)
+		handleBadRequest(c, "SignUp", "failed unmarshalling request body", err)
 
 		return
 	}
 
 	if err := user.Validate(); err != nil {
-		log.WithFields(log.Fields{
-			"handler": "SignUp",
-			"issue":   "wrong params",
-		}).Error(err)
-		c.Writer.WriteHeader(http.StatusBadRequest)
+		handleError(c, "SignUp", err)
 
 		return
 	}
 
 	if _, err := h.usersService.Create(context.TODO(), user); err != nil {
-		log.WithFields(log.Fields{
-			"handler": "SignUp",
-			"issue":   "internal error",
-		}).Error(err)
-		c.Writer.WriteHeader(http.StatusInternalServerError)
+		handleError(c, "SignUp", err)
 
 		return
 	}
@@ -69,58 +55,40 @@ func (h *Handler) SignUp(c *gin.Context) {
 //	@Produce		json
 //	@Param			input body domain.SignInInput true "user's info"
 //	@Success 		200 {string} string "token"
-//	@Failure 		400,404 {object} error
-//	@Failure 		500 {object} 	 error
-//	@Failure 		default {object} error
-//	@Router			/auth/sign-in [post]
+//	@Failure 		400,404,422 {object} problem
+//	@Failure 		500 {object} problem
+//	@Failure 		default {object} problem
+//	@Router			/api/v1/auth/sign-in [post]
 func (h *Handler) SignIn(c *gin.Context) {
 	var user domain.SignInInput
 
 	decoder := json.NewDecoder(c.Request.Body)
 	if err := decoder.Decode(&user); err != nil {
-		log.WithFields(log.Fields{
-			"handler": "SignIn",
-			"issue":   "failed unmarshalling request body",
-		}).Error(err)
-		c.Writer.WriteHeader(http.StatusBadRequest)
+		handleBadRequest(c, "SignIn", "failed unmarshalling request body", err)
 
 		return
 	}
 
 	if err := user.Validate(); err != nil {
-		log.WithFields(log.Fields{
-			"handler": "SignIn",
-			"issue":   "wrong params",
-		}).Error(err)
-		c.Writer.WriteHeader(http.StatusBadRequest)
+		handleError(c, "SignIn", err)
 
 		return
 	}
 
-	accessToken, refreshToken, err := h.usersService.GetToken(context.TODO(), user)
+	accessToken, refreshToken, err := h.usersService.GetToken(context.TODO(), user, getClientInfo(c, user.Device))
 	if err != nil {
-		if errors.Is(err, domain.ErrUserNotFound) {
-			handleNotFoundError(c.Writer, err)
-
-			return
-		}
-
-		log.WithFields(log.Fields{
-			"handler": "SignIn",
-			"issue":   "internal error",
-		}).Error(err)
-		c.Writer.WriteHeader(http.StatusInternalServerError)
+		handleError(c, "SignIn", err)
 
 		return
 	}
 
+	c.Writer.Header().Add("Set-Cookie", fmt.Sprintf("refresh-token=%s; HttpOnly", refreshToken))
+	c.Writer.Header().Add("Content-Type", "application/json")
+
 	encoder := json.NewEncoder(c.Writer)
 	encoder.Encode(map[string]string{
 		"token": accessToken,
 	})
-
-	c.Writer.Header().Add("Set-Cookie", fmt.Sprintf("refresh-token=%s; HttpOnly", refreshToken))
-	c.Writer.Header().Add("Content-Type", "application/json")
 }
 
 // Auth godoc
@@ -131,45 +99,50 @@ func (h *Handler) SignIn(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Success 		200 {string} string "token"
-//	@Failure		400,404,500  {object} error
-//	@Router			/auth/refresh [get]
+//	@Failure		400,401,404,500  {object} problem
+//	@Router			/api/v1/auth/refresh [get]
 func (h *Handler) Refresh(c *gin.Context) {
 	c.Writer.Header().Add("Content-Type", "application/json")
 
 	cookie, err := c.Cookie("refresh-token")
 	if err != nil {
-		log.WithFields(log.Fields{
-			"handler": "Refresh",
-			"issue":   "bad request",
-		}).Error(err)
-		c.Writer.WriteHeader(http.StatusBadRequest)
+		handleBadRequest(c, "Refresh", "no refresh token cookie", err)
 		return
 	}
 
-	accessToken, refreshToken, err := h.usersService.RefreshToken(c, cookie)
+	accessToken, refreshToken, err := h.usersService.RefreshToken(c, cookie, getClientInfo(c, ""))
 	if err != nil {
-		log.WithFields(log.Fields{
-			"handler": "Refresh",
-			"issue":   "internal error",
-		}).Error(err)
-		c.Writer.WriteHeader(http.StatusInternalServerError)
+		handleError(c, "Refresh", err)
 		return
 	}
 
+	c.Header("Set-Cookie", fmt.Sprintf("refresh-token=%s; HttpOnly", refreshToken))
+
 	encoder := json.NewEncoder(c.Writer)
 	encoder.Encode(map[string]string{
 		"token": accessToken,
 	})
-
-	c.Header("Set-Cookie", fmt.Sprintf("refresh-token='%s'; HttpOnly", refreshToken))
 }
 
-func handleNotFoundError(w gin.ResponseWriter, err error) {
-	encoder := json.NewEncoder(w)
-	encoder.Encode(map[string]string{
-		"error": err.Error(),
-	})
+// Auth godoc
+//
+//	@Summary		JWKS
+//	@Description	public keys access tokens are signed with
+//	@Tags			user
+//	@Produce		json
+//	@Success		200	{object} jwtkeys.JWKS
+//	@Router			/.well-known/jwks.json [get]
+func (h *Handler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+
+	jwks := h.usersService.JWKS()
+	writeJSON(c, "JWKS", &jwks)
+}
 
-	w.Header().Add("Content-Type", "application/json")
-	w.WriteHeader(http.StatusBadRequest)
+func getClientInfo(c *gin.Context, device string) domain.ClientInfo {
+	return domain.ClientInfo{
+		Device:    device,
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
 }
@@ -0,0 +1,175 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	"github.com/AngelicaNice/HollywoodStarsCRUD/pkg/jsonpatch"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+const problemContentType = "application/problem+json"
+
+// Error codes sent in the code member of the problem responses. Clients rely
+// on them, so they must not change.
+const (
+	codeBadRequest           = "bad_request"
+	codeValidationFailed     = "validation_failed"
+	codeInvalidCursor        = "invalid_cursor"
+	codeUnauthorized         = "unauthorized"
+	codeInvalidToken         = "invalid_token"
+	codeAccessTokenRevoked   = "access_token_revoked"
+	codeRefreshTokenExpired  = "refresh_token_expired"
+	codeRefreshTokenNotFound = "refresh_token_not_found"
+	codeRefreshTokenRevoked  = "refresh_token_revoked"
+	codeRefreshTokenReused   = "refresh_token_reused"
+	codeForbidden            = "forbidden"
+	codeNotFound             = "not_found"
+	codeActorNotFound        = "actor_not_found"
+	codeMovieNotFound        = "movie_not_found"
+	codeRoleNotFound         = "role_not_found"
+	codeUserNotFound         = "user_not_found"
+	codeSessionNotFound      = "session_not_found"
+	codeRevisionNotFound     = "revision_not_found"
+	codeActorExists          = "actor_exists"
+	codePreconditionFailed   = "precondition_failed"
+	codePreconditionRequired = "precondition_required"
+	codeRoleExists           = "role_exists"
+	codePayloadTooLarge      = "payload_too_large"
+	codeUnsupportedMedia     = "unsupported_media_type"
+	codeInvalidPatch         = "invalid_patch"
+	codePatchPathNotFound    = "patch_path_not_found"
+	codePatchTestFailed      = "patch_test_failed"
+	codeInvalidPatchedActor  = "invalid_patched_actor"
+	codeInternal             = "internal_error"
+)
+
+var (
+	errInvalidToken     = errors.New("invalid access token")
+	errUnsupportedPatch = errors.New("unsupported patch format, use application/merge-patch+json or application/json-patch+json")
+	errPatchedActor     = errors.New("patched document is not an actor")
+)
+
+// problem is an RFC 7807 problem details response. Type is always
+// about:blank, Code tells the errors with the same status apart.
+type problem struct {
+	Type     string              `json:"type"`
+	Title    string              `json:"title"`
+	Status   int                 `json:"status"`
+	Code     string              `json:"code"`
+	Detail   string              `json:"detail,omitempty"`
+	Instance string              `json:"instance,omitempty"`
+	Errors   []domain.FieldError `json:"errors,omitempty"`
+}
+
+// errorStatuses maps the errors to the status and the code of the response,
+// the first matching entry wins.
+var errorStatuses = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{domain.ErrInvalidCursor, http.StatusBadRequest, codeInvalidCursor},
+	{domain.ErrAccessTokenRevoked, http.StatusUnauthorized, codeAccessTokenRevoked},
+	{errInvalidToken, http.StatusUnauthorized, codeInvalidToken},
+	{domain.ErrUnauthenticatedUser, http.StatusUnauthorized, codeUnauthorized},
+	{domain.ErrRefreshTokenExpired, http.StatusUnauthorized, codeRefreshTokenExpired},
+	{domain.ErrRefreshTokenNotFound, http.StatusUnauthorized, codeRefreshTokenNotFound},
+	{domain.ErrRefreshTokenRevoked, http.StatusUnauthorized, codeRefreshTokenRevoked},
+	{domain.ErrRefreshTokenReused, http.StatusUnauthorized, codeRefreshTokenReused},
+	{domain.ErrForbidden, http.StatusForbidden, codeForbidden},
+	{domain.ErrActorNotFound, http.StatusNotFound, codeActorNotFound},
+	{domain.ErrMovieNotFound, http.StatusNotFound, codeMovieNotFound},
+	{domain.ErrRoleNotFound, http.StatusNotFound, codeRoleNotFound},
+	{domain.ErrUserNotFound, http.StatusNotFound, codeUserNotFound},
+	{domain.ErrSessionNotFound, http.StatusNotFound, codeSessionNotFound},
+	{domain.ErrRevisionNotFound, http.StatusNotFound, codeRevisionNotFound},
+	{domain.ErrActorExists, http.StatusConflict, codeActorExists},
+	{domain.ErrRoleExists, http.StatusConflict, codeRoleExists},
+	{domain.ErrActorModified, http.StatusPreconditionFailed, codePreconditionFailed},
+	{errUnknownETag, http.StatusPreconditionFailed, codePreconditionFailed},
+	{errPreconditionRequired, http.StatusPreconditionRequired, codePreconditionRequired},
+	{errUnsupportedFormat, http.StatusUnsupportedMediaType, codeUnsupportedMedia},
+	{errUnsupportedPatch, http.StatusUnsupportedMediaType, codeUnsupportedMedia},
+	{jsonpatch.ErrInvalidPatch, http.StatusBadRequest, codeInvalidPatch},
+	{jsonpatch.ErrPathNotFound, http.StatusUnprocessableEntity, codePatchPathNotFound},
+	{jsonpatch.ErrTestFailed, http.StatusConflict, codePatchTestFailed},
+	{errPatchedActor, http.StatusUnprocessableEntity, codeInvalidPatchedActor},
+}
+
+func newProblem(status int, code, detail string) problem {
+	return problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   code,
+		Detail: detail,
+	}
+}
+
+// problemFor maps err to a problem, errors it doesn't know are internal
+// errors and their text isn't sent to the client.
+func problemFor(err error) problem {
+	var (
+		validationErr *domain.ValidationError
+		maxBytesErr   *http.MaxBytesError
+	)
+
+	if errors.As(err, &validationErr) {
+		p := newProblem(http.StatusUnprocessableEntity, codeValidationFailed, "some fields are invalid")
+		p.Errors = validationErr.Fields
+
+		return p
+	}
+
+	if errors.As(err, &maxBytesErr) {
+		return newProblem(http.StatusRequestEntityTooLarge, codePayloadTooLarge, err.Error())
+	}
+
+	for _, e := range errorStatuses {
+		if errors.Is(err, e.err) {
+			return newProblem(e.status, e.code, err.Error())
+		}
+	}
+
+	return newProblem(http.StatusInternalServerError, codeInternal, "internal error")
+}
+
+// handleError logs err and responds with the problem it maps to.
+func handleError(c *gin.Context, handler string, err error) {
+	p := problemFor(err)
+
+	log.WithFields(log.Fields{
+		"handler": handler,
+		"issue":   p.Code,
+	}).Error(err)
+	writeProblem(c, p)
+}
+
+// handleBadRequest logs err and responds with 400, used when the request
+// can't be read at all.
+func handleBadRequest(c *gin.Context, handler, issue string, err error) {
+	log.WithFields(log.Fields{
+		"handler": handler,
+		"issue":   issue,
+	}).Error(err)
+	writeProblem(c, newProblem(http.StatusBadRequest, codeBadRequest, issue+": "+err.Error()))
+}
+
+func writeProblem(c *gin.Context, p problem) {
+	p.Instance = c.Request.URL.Path
+
+	c.Abort()
+	c.Header("Content-Type", problemContentType)
+	c.Writer.WriteHeader(p.Status)
+
+	if err := json.NewEncoder(c.Writer).Encode(&p); err != nil {
+		log.WithFields(log.Fields{
+			"handler": "writeProblem",
+			"issue":   "failed marshaling response body",
+		}).Error(err)
+	}
+}
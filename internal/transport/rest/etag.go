@@ -0,0 +1,75 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errPreconditionRequired = errors.New("the If-Match header with the ETag of the actor is required")
+	errUnknownETag          = errors.New("the If-Match header doesn't match any version of the actor")
+)
+
+// actorETag is the strong entity tag of the version of the actor.
+func actorETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// requireIfMatch rejects the writes that don't say which version of the actor
+// they change with 428.
+func requireIfMatch(c *gin.Context) {
+	if c.GetHeader("If-Match") == "" {
+		writeProblem(c, problemFor(errPreconditionRequired))
+	}
+}
+
+// ifMatchVersion returns the version the If-Match header of the request asks
+// for, domain.AnyVersion if the header is absent or *. Only a single strong
+// ETag can match a version, anything else fails with errUnknownETag.
+func ifMatchVersion(c *gin.Context) (int64, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return domain.AnyVersion, nil
+	}
+
+	tag, ok := strings.CutPrefix(header, `"`)
+	if tag, ok = strings.CutSuffix(tag, `"`); !ok {
+		return 0, errUnknownETag
+	}
+
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, errUnknownETag
+	}
+
+	return version, nil
+}
+
+// notModified tells if the If-None-Match header of the request lists etag,
+// comparing the tags weakly as RFC 9110 requires.
+func notModified(c *gin.Context, etag string) bool {
+	header := c.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeNotModified answers a conditional GET whose ETag didn't change.
+func writeNotModified(c *gin.Context) {
+	c.Status(http.StatusNotModified)
+	c.Writer.WriteHeaderNow()
+}
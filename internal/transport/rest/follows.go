@@ -0,0 +1,139 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// Auth godoc
+//
+//	@Summary		Follow actor
+//	@Security 		ApiKeyAuth
+//	@Description	follow actor by id, following twice is not an error
+//	@Tags			follow
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	int	true	"actor id"	minimum(1)
+//	@Success		200	{integer} integer 1
+//	@Failure		400,401,404,500 {object} problem
+//	@Router			/api/v1/actors/{id}/follow [post]
+func (h *Handler) FollowActor(c *gin.Context) {
+	userId, actorId, ok := getFollowParams(c, "FollowActor")
+	if !ok {
+		return
+	}
+
+	if err := h.followsService.Follow(c.Request.Context(), userId, actorId); err != nil {
+		handleError(c, "FollowActor", err)
+
+		return
+	}
+
+	c.Writer.WriteHeader(http.StatusOK)
+}
+
+// Auth godoc
+//
+//	@Summary		Unfollow actor
+//	@Security 		ApiKeyAuth
+//	@Description	unfollow actor by id
+//	@Tags			follow
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	int	true	"actor id"	minimum(1)
+//	@Success		200	{integer} integer 1
+//	@Failure		400,401,500 {object} problem
+//	@Router			/api/v1/actors/{id}/follow [delete]
+func (h *Handler) UnfollowActor(c *gin.Context) {
+	userId, actorId, ok := getFollowParams(c, "UnfollowActor")
+	if !ok {
+		return
+	}
+
+	if err := h.followsService.Unfollow(c.Request.Context(), userId, actorId); err != nil {
+		handleError(c, "UnfollowActor", err)
+
+		return
+	}
+
+	c.Writer.WriteHeader(http.StatusOK)
+}
+
+// Auth godoc
+//
+//	@Summary		Get followed actors
+//	@Security 		ApiKeyAuth
+//	@Description	get actors followed by the current user
+//	@Tags			follow
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array} domain.FollowedActor
+//	@Failure		401,500 {object} problem
+//	@Router			/api/v1/me/follows [get]
+func (h *Handler) GetMyFollows(c *gin.Context) {
+	userId, ok := domain.UserIDFromContext(c.Request.Context())
+	if !ok {
+		handleError(c, "GetMyFollows", domain.ErrUnauthenticatedUser)
+
+		return
+	}
+
+	follows, err := h.followsService.GetFollows(c.Request.Context(), userId)
+	if err != nil {
+		handleError(c, "GetMyFollows", err)
+
+		return
+	}
+
+	writeJSON(c, "GetMyFollows", &follows)
+}
+
+// Auth godoc
+//
+//	@Summary		Count actor's followers
+//	@Security 		ApiKeyAuth
+//	@Description	get number of users following the actor
+//	@Tags			follow
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	int	true	"actor id"	minimum(1)
+//	@Success		200	{object} domain.FollowersCount
+//	@Failure		400,404,500 {object} problem
+//	@Router			/api/v1/actors/{id}/followers/count [get]
+func (h *Handler) CountFollowers(c *gin.Context) {
+	actorId, err := getIdFromParam(c, "id")
+	if err != nil {
+		handleBadRequest(c, "CountFollowers", "failed reading request param", err)
+
+		return
+	}
+
+	count, err := h.followsService.CountFollowers(c.Request.Context(), actorId)
+	if err != nil {
+		handleError(c, "CountFollowers", err)
+
+		return
+	}
+
+	writeJSON(c, "CountFollowers", &domain.FollowersCount{ActorID: actorId, Count: count})
+}
+
+func getFollowParams(c *gin.Context, handler string) (int64, int64, bool) {
+	userId, ok := domain.UserIDFromContext(c.Request.Context())
+	if !ok {
+		handleError(c, handler, domain.ErrUnauthenticatedUser)
+
+		return 0, 0, false
+	}
+
+	actorId, err := getIdFromParam(c, "id")
+	if err != nil {
+		handleBadRequest(c, handler, "failed reading request param", err)
+
+		return 0, 0, false
+	}
+
+	return userId, actorId, true
+}
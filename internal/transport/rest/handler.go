@@ -2,11 +2,15 @@ package rest
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+	"time"
 
 	_ "github.com/AngelicaNice/HollywoodStarsCRUD/docs"
 	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	"github.com/AngelicaNice/HollywoodStarsCRUD/pkg/jwtkeys"
 	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
 	swaggerFiles "github.com/swaggo/files"     // swagger embed files
 	ginSwagger "github.com/swaggo/gin-swagger" // gin-swagger middleware
 )
@@ -14,53 +18,192 @@ import (
 type Actors interface {
 	Create(ctx context.Context, actor domain.ActorInput) (int64, error)
 	GetByID(ctx context.Context, id int64) (domain.Actor, error)
-	GetAllActors(ctx context.Context) ([]domain.Actor, error)
-	Update(ctx context.Context, id int64, info domain.UpdateActorInfo) error
+	GetAllActors(ctx context.Context, opts domain.ActorsListOptions) (domain.ActorsPage, error)
+	Update(ctx context.Context, id, version int64, info domain.UpdateActorInfo) (domain.Actor, error)
+	Replace(ctx context.Context, id, version int64, actor domain.ActorInput) (domain.Actor, error)
+	Patch(ctx context.Context, id, version int64, patch func(actor domain.Actor) (domain.ActorInput, error)) (domain.Actor, error)
+	Delete(ctx context.Context, id, version int64) error
+	GetDeleted(ctx context.Context) ([]domain.DeletedActor, error)
+	Restore(ctx context.Context, id int64) (domain.Actor, error)
+	Purge(ctx context.Context, id int64) error
+	GetRevisions(ctx context.Context, id int64) ([]domain.ActorRevision, error)
+	GetRevision(ctx context.Context, id, revision int64) (domain.ActorRevision, error)
+	DiffRevisions(ctx context.Context, id, from, to int64) (domain.ActorRevisionsDiff, error)
+	Revert(ctx context.Context, id, revision, version int64) (domain.Actor, error)
+	Search(ctx context.Context, input domain.ActorsSearchInput) ([]domain.ActorSearchResult, error)
+	CreateBatch(ctx context.Context, actors []domain.ActorInput) ([]domain.Actor, error)
+	Export(ctx context.Context, fn func(actor domain.Actor) error) error
+}
+
+type Movies interface {
+	Create(ctx context.Context, movie domain.MovieInput) (int64, error)
+	GetByID(ctx context.Context, id int64) (domain.Movie, error)
+	GetAll(ctx context.Context) ([]domain.Movie, error)
+	Update(ctx context.Context, id int64, info domain.UpdateMovieInfo) error
 	Delete(ctx context.Context, id int64) error
+	AddRole(ctx context.Context, movieId int64, role domain.RoleInput) (int64, error)
+	DeleteRole(ctx context.Context, movieId, roleId int64) error
+	GetCast(ctx context.Context, movieId int64) ([]domain.CastMember, error)
+	GetFilmography(ctx context.Context, actorId int64) ([]domain.FilmographyItem, error)
+}
+
+type Follows interface {
+	Follow(ctx context.Context, userId, actorId int64) error
+	Unfollow(ctx context.Context, userId, actorId int64) error
+	GetFollows(ctx context.Context, userId int64) ([]domain.FollowedActor, error)
+	CountFollowers(ctx context.Context, actorId int64) (int64, error)
 }
 
 type Users interface {
 	Create(ctx context.Context, user domain.SignUpInput) (int64, error)
-	GetToken(ctx context.Context, input domain.SignInInput) (string, string, error)
-	ParseToken(ctx context.Context, token string) (int64, error)
-	RefreshToken(ctx context.Context, refreshToken string) (string, string, error)
-	GenerateTokens(ctx context.Context, id int64) (string, string, error)
+	GetToken(ctx context.Context, input domain.SignInInput, client domain.ClientInfo) (string, string, error)
+	ParseToken(ctx context.Context, token string) (domain.TokenClaims, error)
+	RefreshToken(ctx context.Context, refreshToken string, client domain.ClientInfo) (string, string, error)
+	GenerateTokens(ctx context.Context, id int64, role domain.Role, client domain.ClientInfo) (string, string, error)
+	UpdateRole(ctx context.Context, id int64, role domain.Role) error
+	Logout(ctx context.Context, claims domain.TokenClaims) error
+	LogoutAll(ctx context.Context, claims domain.TokenClaims) error
+	GetSessions(ctx context.Context, claims domain.TokenClaims) ([]domain.Session, error)
+	RevokeSession(ctx context.Context, userId int64, sessionId string) error
+	JWKS() jwtkeys.JWKS
+}
+
+// HealthChecker is a dependency reported by the health check, Healthy returns
+// nil if it is available.
+type HealthChecker interface {
+	Healthy() error
 }
 
 type Handler struct {
-	actorsService Actors
-	usersService  Users
+	actorsService  Actors
+	moviesService  Movies
+	followsService Follows
+	usersService   Users
+	healthChecks   map[string]HealthChecker
 }
 
-func NewHandler(a Actors, u Users) *Handler {
+func NewHandler(a Actors, m Movies, f Follows, u Users, hc map[string]HealthChecker) *Handler {
 	return &Handler{
-		actorsService: a,
-		usersService:  u,
+		actorsService:  a,
+		moviesService:  m,
+		followsService: f,
+		usersService:   u,
+		healthChecks:   hc,
 	}
 }
 
+// The unversioned routes are deprecated in favour of /api/v1.
+var (
+	legacyDeprecation = time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+	legacySunset      = time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC)
+)
+
 func (h *Handler) InitRouter() *gin.Engine {
 	r := gin.Default()
 
 	r.Use(gin.Logger())
 	r.Use(gin.Recovery())
 
+	v1 := r.Group("/api/v1")
+	h.initRoutes(v1)
+
+	actors := v1.Group("/actors").Use(authMiddleware(h))
+	{
+		actors.Handle(http.MethodGet, "/:id", h.GetActor)
+		actors.Handle(http.MethodPut, "/:id", requireRole(domain.RoleEditor), requireIfMatch, h.ReplaceActor)
+		actors.Handle(http.MethodPatch, "/:id", requireRole(domain.RoleEditor), requireIfMatch, h.PatchActor)
+		actors.Handle(http.MethodDelete, "/:id", requireRole(domain.RoleAdmin), requireIfMatch, h.DeleteActor)
+	}
+
+	// the unversioned routes stay until legacySunset, the actor ones take
+	// the id from the query: /actors/id?id=5 and If-Match is optional
+	legacy := r.Group("", deprecated(legacyDeprecation, legacySunset))
+	h.initRoutes(legacy)
+
+	legacyActors := legacy.Group("/actors").Use(authMiddleware(h), idFromQuery)
+	{
+		legacyActors.Handle(http.MethodGet, "/id", h.GetActor)
+		legacyActors.Handle(http.MethodPut, "/id", requireRole(domain.RoleEditor), h.UpdateActor)
+		legacyActors.Handle(http.MethodDelete, "/id", requireRole(domain.RoleAdmin), h.DeleteActor)
+	}
+
+	r.GET("/health", h.Health)
+	r.GET("/.well-known/jwks.json", h.JWKS)
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	r.NoRoute(func(c *gin.Context) {
+		writeProblem(c, newProblem(http.StatusNotFound, codeNotFound, "no such route"))
+	})
+
+	return r
+}
+
+// initRoutes registers the routes served both under /api/v1 and unversioned.
+func (h *Handler) initRoutes(r *gin.RouterGroup) {
 	auth := r.Group("/auth")
 	{
 		auth.Handle(http.MethodPost, "/sign-up", h.SignUp)
 		auth.Handle(http.MethodPost, "/sign-in", h.SignIn)
 		auth.Handle(http.MethodGet, "/refresh", h.Refresh)
+		auth.Handle(http.MethodPost, "/logout", authMiddleware(h), h.Logout)
+		auth.Handle(http.MethodPost, "/logout-all", authMiddleware(h), h.LogoutAll)
 	}
 
 	api := r.Group("/actors").Use(authMiddleware(h))
 	{
-		api.Handle(http.MethodPost, "", h.AddActor)
+		api.Handle(http.MethodPost, "", requireRole(domain.RoleEditor), h.AddActor)
 		api.Handle(http.MethodGet, "", h.GetAllActors)
-		api.Handle(http.MethodGet, "/id", h.GetActor)
-		api.Handle(http.MethodPut, "/id", h.UpdateActor)
-		api.Handle(http.MethodDelete, "/id", h.DeleteActor)
+		api.Handle(http.MethodGet, "/search", h.SearchActors)
+		api.Handle(http.MethodPost, "/import", requireRole(domain.RoleEditor), h.ImportActors)
+		api.Handle(http.MethodGet, "/export", h.ExportActors)
+		api.Handle(http.MethodGet, "/:id/filmography", h.GetFilmography)
+		api.Handle(http.MethodPost, "/:id/follow", h.FollowActor)
+		api.Handle(http.MethodDelete, "/:id/follow", h.UnfollowActor)
+		api.Handle(http.MethodGet, "/:id/followers/count", h.CountFollowers)
+		api.Handle(http.MethodPost, "/:id/restore", requireRole(domain.RoleAdmin), h.RestoreActor)
+		api.Handle(http.MethodGet, "/:id/revisions", h.GetActorRevisions)
+		api.Handle(http.MethodGet, "/:id/revisions/diff", h.DiffActorRevisions)
+		api.Handle(http.MethodGet, "/:id/revisions/:rev", h.GetActorRevision)
+		api.Handle(http.MethodPost, "/:id/revisions/:rev/revert", requireRole(domain.RoleEditor), requireIfMatch, h.RevertActor)
 	}
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	return r
+	me := r.Group("/me").Use(authMiddleware(h))
+	{
+		me.Handle(http.MethodGet, "/follows", h.GetMyFollows)
+		me.Handle(http.MethodGet, "/sessions", h.GetMySessions)
+		me.Handle(http.MethodDelete, "/sessions/:id", h.RevokeMySession)
+	}
+
+	movies := r.Group("/movies").Use(authMiddleware(h))
+	{
+		movies.Handle(http.MethodPost, "", requireRole(domain.RoleEditor), h.AddMovie)
+		movies.Handle(http.MethodGet, "", h.GetAllMovies)
+		movies.Handle(http.MethodGet, "/:id", h.GetMovie)
+		movies.Handle(http.MethodPut, "/:id", requireRole(domain.RoleEditor), h.UpdateMovie)
+		movies.Handle(http.MethodDelete, "/:id", requireRole(domain.RoleAdmin), h.DeleteMovie)
+		movies.Handle(http.MethodGet, "/:id/cast", h.GetMovieCast)
+		movies.Handle(http.MethodPost, "/:id/cast", requireRole(domain.RoleEditor), h.AddMovieRole)
+		movies.Handle(http.MethodDelete, "/:id/cast/:role_id", requireRole(domain.RoleEditor), h.DeleteMovieRole)
+	}
+
+	admin := r.Group("/admin").Use(authMiddleware(h), requireRole(domain.RoleAdmin))
+	{
+		admin.Handle(http.MethodPut, "/users/:id/role", h.UpdateUserRole)
+		admin.Handle(http.MethodGet, "/trash/actors", h.GetTrashedActors)
+		admin.Handle(http.MethodDelete, "/trash/actors/:id", h.PurgeActor)
+	}
+}
+
+func writeJSON(c *gin.Context, handler string, v interface{}) {
+	c.Writer.Header().Add("Content-Type", "application/json")
+
+	encoder := json.NewEncoder(c.Writer)
+
+	if err := encoder.Encode(v); err != nil {
+		log.WithFields(log.Fields{
+			"handler": handler,
+			"issue":   "failed marshaling response body",
+		}).Error(err)
+		c.Writer.WriteHeader(http.StatusInternalServerError)
+	}
 }
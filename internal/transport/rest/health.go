@@ -0,0 +1,45 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type healthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// Health godoc
+//
+//	@Summary		Health
+//	@Description	state of the service dependencies
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object} healthResponse
+//	@Failure		503	{object} healthResponse
+//	@Router			/health [get]
+func (h *Handler) Health(c *gin.Context) {
+	resp := healthResponse{
+		Status: "ok",
+		Checks: make(map[string]string, len(h.healthChecks)),
+	}
+
+	for name, checker := range h.healthChecks {
+		if err := checker.Healthy(); err != nil {
+			resp.Status = "unavailable"
+			resp.Checks[name] = err.Error()
+
+			continue
+		}
+
+		resp.Checks[name] = "ok"
+	}
+
+	if resp.Status != "ok" {
+		c.Writer.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	writeJSON(c, "Health", &resp)
+}
@@ -0,0 +1,392 @@
+package rest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	maxImportSize   = 10 << 20
+	exportFlushRows = 100
+)
+
+var (
+	errUnsupportedFormat = errors.New("unsupported format, use text/csv, application/json or application/x-ndjson")
+	errMissingColumn     = errors.New("missing column")
+)
+
+var actorsCSVColumns = []string{"name", "surname", "sex", "birth_year", "birth_place", "rest_year", "language"}
+
+type importRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+type importResponse struct {
+	DryRun  bool             `json:"dry_run"`
+	Total   int              `json:"total"`
+	Created int              `json:"created"`
+	Errors  []importRowError `json:"errors,omitempty"`
+}
+
+// Auth godoc
+//
+//	@Summary		Import actors
+//	@Security 		ApiKeyAuth
+//	@Description	import actors from CSV (with a header row), a JSON array or NDJSON, chosen by Content-Type.
+//	@Description	With dry_run rows are only validated; otherwise all of them are created or, if any is invalid, none.
+//	@Tags			actor
+//	@Accept			text/csv,json,application/x-ndjson
+//	@Produce		json
+//	@Param			dry_run	query	bool	false	"only validate the rows"
+//	@Success		200,201	{object} importResponse
+//	@Failure		400,409,413,415,500 {object} problem
+//	@Failure		422 {object} importResponse
+//	@Router			/api/v1/actors/import [post]
+func (h *Handler) ImportActors(c *gin.Context) {
+	dryRun, _ := strconv.ParseBool(c.Query("dry_run"))
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+
+	actors, rowErrors, err := parseActorsImport(c.ContentType(), body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+
+		if errors.As(err, &maxBytesErr) || errors.Is(err, errUnsupportedFormat) {
+			handleError(c, "ImportActors", err)
+		} else {
+			handleBadRequest(c, "ImportActors", "failed parsing request body", err)
+		}
+
+		return
+	}
+
+	resp := importResponse{
+		DryRun: dryRun,
+		Total:  len(actors) + len(rowErrors),
+		Errors: rowErrors,
+	}
+
+	if len(rowErrors) > 0 {
+		if !dryRun {
+			c.Writer.WriteHeader(http.StatusUnprocessableEntity)
+		}
+
+		writeJSON(c, "ImportActors", &resp)
+
+		return
+	}
+
+	if dryRun {
+		writeJSON(c, "ImportActors", &resp)
+
+		return
+	}
+
+	created, err := h.actorsService.CreateBatch(c.Request.Context(), actors)
+	if err != nil {
+		handleError(c, "ImportActors", err)
+
+		return
+	}
+
+	resp.Created = len(created)
+
+	c.Writer.WriteHeader(http.StatusCreated)
+	writeJSON(c, "ImportActors", &resp)
+}
+
+// Auth godoc
+//
+//	@Summary		Export actors
+//	@Security 		ApiKeyAuth
+//	@Description	stream all actors as CSV or NDJSON
+//	@Tags			actor
+//	@Produce		text/csv,application/x-ndjson
+//	@Param			format	query	string	false	"export format"	Enums(csv, ndjson)
+//	@Success		200
+//	@Failure		400,500 {object} problem
+//	@Router			/api/v1/actors/export [get]
+func (h *Handler) ExportActors(c *gin.Context) {
+	var (
+		write func(actor domain.Actor) error
+		flush func() error
+	)
+
+	format := c.DefaultQuery("format", "ndjson")
+
+	switch format {
+	case "csv":
+		w := csv.NewWriter(c.Writer)
+		write = func(actor domain.Actor) error {
+			return w.Write(actorCSVRecord(actor))
+		}
+		flush = func() error {
+			w.Flush()
+
+			return w.Error()
+		}
+
+		c.Header("Content-Type", "text/csv")
+
+		if err := w.Write(append([]string{"id"}, actorsCSVColumns...)); err != nil {
+			handleError(c, "ExportActors", err)
+
+			return
+		}
+	case "ndjson":
+		encoder := json.NewEncoder(c.Writer)
+		write = func(actor domain.Actor) error {
+			return encoder.Encode(&actor)
+		}
+		flush = func() error {
+			return nil
+		}
+
+		c.Header("Content-Type", "application/x-ndjson")
+	default:
+		handleBadRequest(c, "ExportActors", "unsupported format", errors.New(format))
+
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=actors."+format)
+
+	rows := 0
+
+	err := h.actorsService.Export(c.Request.Context(), func(actor domain.Actor) error {
+		if err := write(actor); err != nil {
+			return err
+		}
+
+		if rows++; rows%exportFlushRows == 0 {
+			if err := flush(); err != nil {
+				return err
+			}
+
+			c.Writer.Flush()
+		}
+
+		return nil
+	})
+	if err == nil {
+		err = flush()
+	}
+
+	if err != nil {
+		if !c.Writer.Written() {
+			handleError(c, "ExportActors", err)
+
+			return
+		}
+
+		// the status has been sent already, the client sees a cut off body
+		log.WithFields(log.Fields{
+			"handler": "ExportActors",
+			"issue":   "failed streaming actors",
+		}).Error(err)
+	}
+}
+
+// parseActorsImport decodes the actors in the format given by contentType.
+// Rows that can't be decoded or are invalid are returned as row errors, rows
+// are numbered from 1 not counting the CSV header.
+func parseActorsImport(contentType string, r io.Reader) ([]domain.ActorInput, []importRowError, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch mediaType {
+	case "text/csv":
+		return parseActorsCSV(r)
+	case "application/json":
+		return parseActorsJSON(r)
+	case "application/x-ndjson", "application/jsonl":
+		return parseActorsNDJSON(r)
+	}
+
+	return nil, nil, errUnsupportedFormat
+}
+
+func parseActorsJSON(r io.Reader) ([]domain.ActorInput, []importRowError, error) {
+	decoder := json.NewDecoder(r)
+
+	if tok, err := decoder.Token(); err != nil {
+		return nil, nil, err
+	} else if tok != json.Delim('[') {
+		return nil, nil, errors.New("expected a JSON array")
+	}
+
+	actors := make([]domain.ActorInput, 0)
+	rowErrors := make([]importRowError, 0)
+
+	for row := 1; decoder.More(); row++ {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, nil, err
+		}
+
+		actors, rowErrors = addImportRow(actors, rowErrors, row, func(actor *domain.ActorInput) error {
+			return json.Unmarshal(raw, actor)
+		})
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, nil, err
+	}
+
+	return actors, rowErrors, nil
+}
+
+func parseActorsNDJSON(r io.Reader) ([]domain.ActorInput, []importRowError, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportSize)
+
+	actors := make([]domain.ActorInput, 0)
+	rowErrors := make([]importRowError, 0)
+
+	row := 0
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		row++
+
+		actors, rowErrors = addImportRow(actors, rowErrors, row, func(actor *domain.ActorInput) error {
+			return json.Unmarshal(line, actor)
+		})
+	}
+
+	return actors, rowErrors, scanner.Err()
+}
+
+func parseActorsCSV(r io.Reader) ([]domain.ActorInput, []importRowError, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[name] = i
+	}
+
+	for _, name := range actorsCSVColumns[:5] {
+		if _, ok := columns[name]; !ok {
+			return nil, nil, fmt.Errorf("%w %s", errMissingColumn, name)
+		}
+	}
+
+	actors := make([]domain.ActorInput, 0)
+	rowErrors := make([]importRowError, 0)
+
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if err != nil && !errors.As(err, &parseErr) {
+			return nil, nil, err
+		}
+
+		actors, rowErrors = addImportRow(actors, rowErrors, row, func(actor *domain.ActorInput) error {
+			if err != nil {
+				return err
+			}
+
+			return actorFromCSV(actor, record, columns)
+		})
+	}
+
+	return actors, rowErrors, nil
+}
+
+func actorFromCSV(actor *domain.ActorInput, record []string, columns map[string]int) error {
+	get := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+
+		return ""
+	}
+
+	var err error
+
+	actor.Name = get("name")
+	actor.Surname = get("surname")
+	actor.Sex = get("sex")
+	actor.BirthPlace = get("birth_place")
+
+	if actor.BirthYear, err = strconv.Atoi(get("birth_year")); err != nil {
+		return fmt.Errorf("birth_year: %w", err)
+	}
+
+	if v := get("rest_year"); v != "" {
+		restYear, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("rest_year: %w", err)
+		}
+
+		actor.RestYear = &restYear
+	}
+
+	if v := get("language"); v != "" {
+		actor.Language = &v
+	}
+
+	return nil
+}
+
+func actorCSVRecord(actor domain.Actor) []string {
+	restYear, language := "", ""
+
+	if actor.RestYear != nil {
+		restYear = strconv.Itoa(*actor.RestYear)
+	}
+
+	if actor.Language != nil {
+		language = *actor.Language
+	}
+
+	return []string{
+		strconv.FormatInt(actor.ID, 10), actor.Name, actor.Surname, actor.Sex,
+		strconv.Itoa(actor.BirthYear), actor.BirthPlace, restYear, language,
+	}
+}
+
+// addImportRow decodes a row with decode and validates it, adding it either
+// to actors or to rowErrors.
+func addImportRow(actors []domain.ActorInput, rowErrors []importRowError, row int,
+	decode func(actor *domain.ActorInput) error,
+) ([]domain.ActorInput, []importRowError) {
+	var actor domain.ActorInput
+
+	err := decode(&actor)
+	if err == nil {
+		err = actor.Validate()
+	}
+
+	if err != nil {
+		return actors, append(rowErrors, importRowError{Row: row, Error: err.Error()})
+	}
+
+	return append(actors, actor), rowErrors
+}
@@ -1,11 +1,14 @@
 package rest
 
 import (
-	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 )
@@ -23,26 +26,60 @@ func authMiddleware(h *Handler) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		token, err := getTokenFromRequest(c.Request)
 		if err != nil {
-			log.WithField("authMiddleware", "getting token").Error(err)
-			c.Writer.WriteHeader(http.StatusUnauthorized)
+			handleError(c, "authMiddleware", fmt.Errorf("%w: %w", errInvalidToken, err))
 
 			return
 		}
 
-		userId, err := h.usersService.ParseToken(c.Request.Context(), token)
+		claims, err := h.usersService.ParseToken(c.Request.Context(), token)
 		if err != nil {
-			log.WithField("authMiddleware", "parsing token").Error(err)
-			c.Writer.WriteHeader(http.StatusUnauthorized)
+			handleError(c, "authMiddleware", fmt.Errorf("%w: %w", errInvalidToken, err))
 
 			return
 		}
-		//ctx := context.WithValue(r.Context(), ctxUserID, userId)
-		//r = r.WithContext(ctx)
-		ctx := context.WithValue(c.Request.Context(), "id", userId)
+
+		ctx := domain.WithUserID(c.Request.Context(), claims.UserID)
+		ctx = domain.WithRole(ctx, claims.Role)
+		ctx = domain.WithTokenClaims(ctx, claims)
 		c.Request = c.Request.WithContext(ctx)
 	}
 }
 
+// requireRole lets the request through only if the role put into the context
+// by authMiddleware grants the required one.
+func requireRole(required domain.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, ok := domain.RoleFromContext(c.Request.Context())
+		if !ok || !role.Allows(required) {
+			log.WithFields(log.Fields{
+				"requireRole": required,
+				"role":        role,
+			}).Error(domain.ErrForbidden)
+			writeProblem(c, problemFor(domain.ErrForbidden))
+
+			return
+		}
+	}
+}
+
+// deprecated marks the responses of the routes that will be removed at
+// sunset with the Deprecation (RFC 9745) and Sunset (RFC 8594) headers.
+func deprecated(deprecation, sunset time.Time) gin.HandlerFunc {
+	deprecationHeader := "@" + strconv.FormatInt(deprecation.Unix(), 10)
+	sunsetHeader := sunset.UTC().Format(http.TimeFormat)
+
+	return func(c *gin.Context) {
+		c.Header("Deprecation", deprecationHeader)
+		c.Header("Sunset", sunsetHeader)
+	}
+}
+
+// idFromQuery copies the id query parameter of the legacy actor routes to the
+// path params, so the handlers read it the same way as under /api/v1.
+func idFromQuery(c *gin.Context) {
+	c.Params = append(c.Params, gin.Param{Key: "id", Value: c.Query("id")})
+}
+
 func getTokenFromRequest(r *http.Request) (string, error) {
 	header := r.Header.Get("Authorization")
 
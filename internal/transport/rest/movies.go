@@ -0,0 +1,315 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// Auth godoc
+//
+//	@Summary		Add movie
+//	@Security 		ApiKeyAuth
+//	@Description	add movie info
+//	@Tags			movie
+//	@Accept			json
+//	@Produce		json
+//	@Param			input body domain.MovieInput true "movie's info"
+//	@Success		201	{integer} integer 1
+//	@Failure		400,404,422,500 {object} problem
+//	@Router			/api/v1/movies [post]
+func (h *Handler) AddMovie(c *gin.Context) {
+	var movie domain.MovieInput
+
+	decoder := json.NewDecoder(c.Request.Body)
+	if err := decoder.Decode(&movie); err != nil {
+		handleBadRequest(c, "AddMovie", "failed unmarshalling request body", err)
+
+		return
+	}
+
+	if err := movie.Validate(); err != nil {
+		handleError(c, "AddMovie", err)
+
+		return
+	}
+
+	if _, err := h.moviesService.Create(context.TODO(), movie); err != nil {
+		handleError(c, "AddMovie", err)
+
+		return
+	}
+
+	c.Writer.WriteHeader(http.StatusCreated)
+}
+
+// Auth godoc
+//
+//	@Summary		Get all movies
+//	@Security 		ApiKeyAuth
+//	@Description	get all movies info
+//	@Tags			movie
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array} domain.Movie
+//	@Failure		400,404,500 {object} problem
+//	@Router			/api/v1/movies [get]
+func (h *Handler) GetAllMovies(c *gin.Context) {
+	movies, err := h.moviesService.GetAll(context.TODO())
+	if err != nil {
+		handleError(c, "GetAllMovies", err)
+
+		return
+	}
+
+	writeJSON(c, "GetAllMovies", &movies)
+}
+
+// Auth godoc
+//
+//	@Summary		Get movie by id
+//	@Security 		ApiKeyAuth
+//	@Description	get movie info by id
+//	@Tags			movie
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	int	true	"movie id"	minimum(1)
+//	@Success		200	{object} domain.Movie
+//	@Failure		400,404,500 {object} problem
+//	@Router			/api/v1/movies/{id} [get]
+func (h *Handler) GetMovie(c *gin.Context) {
+	id, err := getIdFromParam(c, "id")
+	if err != nil {
+		handleBadRequest(c, "GetMovie", "failed reading request param", err)
+
+		return
+	}
+
+	movie, err := h.moviesService.GetByID(context.TODO(), id)
+	if err != nil {
+		handleError(c, "GetMovie", err)
+
+		return
+	}
+
+	writeJSON(c, "GetMovie", &movie)
+}
+
+// Auth godoc
+//
+//	@Summary		Update movie by id
+//	@Security 		ApiKeyAuth
+//	@Description	update movie info by id
+//	@Tags			movie
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	int	true	"movie id"	minimum(1)
+//	@Param			input body domain.UpdateMovieInfo true "new movie's info"
+//	@Success		200	{integer} integer 1
+//	@Failure		400,404,422,500 {object} problem
+//	@Router			/api/v1/movies/{id} [put]
+func (h *Handler) UpdateMovie(c *gin.Context) {
+	id, err := getIdFromParam(c, "id")
+	if err != nil {
+		handleBadRequest(c, "UpdateMovie", "failed reading request param", err)
+
+		return
+	}
+
+	var src domain.UpdateMovieInfo
+
+	decoder := json.NewDecoder(c.Request.Body)
+	if err := decoder.Decode(&src); err != nil {
+		handleBadRequest(c, "UpdateMovie", "bad request", err)
+
+		return
+	}
+
+	if err := src.Validate(); err != nil {
+		handleError(c, "UpdateMovie", err)
+
+		return
+	}
+
+	if err := h.moviesService.Update(context.TODO(), id, src); err != nil {
+		handleError(c, "UpdateMovie", err)
+
+		return
+	}
+
+	c.Writer.WriteHeader(http.StatusOK)
+}
+
+// Auth godoc
+//
+//	@Summary		Delete movie by id
+//	@Security 		ApiKeyAuth
+//	@Description	delete movie with its cast by id
+//	@Tags			movie
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	int	true	"movie id"	minimum(1)
+//	@Success		200	{integer} integer 1
+//	@Failure		400,404,500 {object} problem
+//	@Router			/api/v1/movies/{id} [delete]
+func (h *Handler) DeleteMovie(c *gin.Context) {
+	id, err := getIdFromParam(c, "id")
+	if err != nil {
+		handleBadRequest(c, "DeleteMovie", "failed reading request param", err)
+
+		return
+	}
+
+	if err := h.moviesService.Delete(context.TODO(), id); err != nil {
+		handleError(c, "DeleteMovie", err)
+
+		return
+	}
+
+	c.Writer.WriteHeader(http.StatusOK)
+}
+
+// Auth godoc
+//
+//	@Summary		Get movie cast
+//	@Security 		ApiKeyAuth
+//	@Description	get actors and their roles in the movie ordered by billing
+//	@Tags			movie
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	int	true	"movie id"	minimum(1)
+//	@Success		200	{array} domain.CastMember
+//	@Failure		400,404,500 {object} problem
+//	@Router			/api/v1/movies/{id}/cast [get]
+func (h *Handler) GetMovieCast(c *gin.Context) {
+	id, err := getIdFromParam(c, "id")
+	if err != nil {
+		handleBadRequest(c, "GetMovieCast", "failed reading request param", err)
+
+		return
+	}
+
+	cast, err := h.moviesService.GetCast(context.TODO(), id)
+	if err != nil {
+		handleError(c, "GetMovieCast", err)
+
+		return
+	}
+
+	writeJSON(c, "GetMovieCast", &cast)
+}
+
+// Auth godoc
+//
+//	@Summary		Add role to movie cast
+//	@Security 		ApiKeyAuth
+//	@Description	add actor's role to the movie
+//	@Tags			movie
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	int	true	"movie id"	minimum(1)
+//	@Param			input body domain.RoleInput true "role's info"
+//	@Success		201	{integer} integer 1
+//	@Failure		400,404,409,422,500 {object} problem
+//	@Router			/api/v1/movies/{id}/cast [post]
+func (h *Handler) AddMovieRole(c *gin.Context) {
+	id, err := getIdFromParam(c, "id")
+	if err != nil {
+		handleBadRequest(c, "AddMovieRole", "failed reading request param", err)
+
+		return
+	}
+
+	var role domain.RoleInput
+
+	decoder := json.NewDecoder(c.Request.Body)
+	if err := decoder.Decode(&role); err != nil {
+		handleBadRequest(c, "AddMovieRole", "failed unmarshalling request body", err)
+
+		return
+	}
+
+	if err := role.Validate(); err != nil {
+		handleError(c, "AddMovieRole", err)
+
+		return
+	}
+
+	if _, err := h.moviesService.AddRole(context.TODO(), id, role); err != nil {
+		handleError(c, "AddMovieRole", err)
+
+		return
+	}
+
+	c.Writer.WriteHeader(http.StatusCreated)
+}
+
+// Auth godoc
+//
+//	@Summary		Remove role from movie cast
+//	@Security 		ApiKeyAuth
+//	@Description	delete actor's role from the movie
+//	@Tags			movie
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	int	true	"movie id"	minimum(1)
+//	@Param			role_id	path	int	true	"role id"	minimum(1)
+//	@Success		200	{integer} integer 1
+//	@Failure		400,404,500 {object} problem
+//	@Router			/api/v1/movies/{id}/cast/{role_id} [delete]
+func (h *Handler) DeleteMovieRole(c *gin.Context) {
+	id, err := getIdFromParam(c, "id")
+	if err != nil {
+		handleBadRequest(c, "DeleteMovieRole", "failed reading request param", err)
+
+		return
+	}
+
+	roleId, err := getIdFromParam(c, "role_id")
+	if err != nil {
+		handleBadRequest(c, "DeleteMovieRole", "failed reading request param", err)
+
+		return
+	}
+
+	if err := h.moviesService.DeleteRole(context.TODO(), id, roleId); err != nil {
+		handleError(c, "DeleteMovieRole", err)
+
+		return
+	}
+
+	c.Writer.WriteHeader(http.StatusOK)
+}
+
+// Auth godoc
+//
+//	@Summary		Get actor's filmography
+//	@Security 		ApiKeyAuth
+//	@Description	get movies the actor appeared in with the played roles
+//	@Tags			actor
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	int	true	"actor id"	minimum(1)
+//	@Success		200	{array} domain.FilmographyItem
+//	@Failure		400,404,500 {object} problem
+//	@Router			/api/v1/actors/{id}/filmography [get]
+func (h *Handler) GetFilmography(c *gin.Context) {
+	id, err := getIdFromParam(c, "id")
+	if err != nil {
+		handleBadRequest(c, "GetFilmography", "failed reading request param", err)
+
+		return
+	}
+
+	filmography, err := h.moviesService.GetFilmography(context.TODO(), id)
+	if err != nil {
+		handleError(c, "GetFilmography", err)
+
+		return
+	}
+
+	writeJSON(c, "GetFilmography", &filmography)
+}
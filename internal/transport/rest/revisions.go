@@ -0,0 +1,164 @@
+package rest
+
+import (
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// Auth godoc
+//
+//	@Summary		Get actor's revisions
+//	@Security 		ApiKeyAuth
+//	@Description	get every revision of the actor with the full actor, the author and the time, the latest first
+//	@Tags			actor
+//	@Produce		json
+//	@Param			id	path	int	true	"actor id"	minimum(1)
+//	@Success		200	{array} domain.ActorRevision
+//	@Failure		400,404,500 {object} problem
+//	@Router			/api/v1/actors/{id}/revisions [get]
+func (h *Handler) GetActorRevisions(c *gin.Context) {
+	id, err := getIdFromParam(c, "id")
+	if err != nil {
+		handleBadRequest(c, "GetActorRevisions", "failed reading request param", err)
+
+		return
+	}
+
+	revisions, err := h.actorsService.GetRevisions(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, "GetActorRevisions", err)
+
+		return
+	}
+
+	writeJSON(c, "GetActorRevisions", &revisions)
+}
+
+// Auth godoc
+//
+//	@Summary		Get actor's revision
+//	@Security 		ApiKeyAuth
+//	@Description	get the actor as it was after the change that made the revision
+//	@Tags			actor
+//	@Produce		json
+//	@Param			id	path	int	true	"actor id"	minimum(1)
+//	@Param			rev	path	int	true	"revision"	minimum(1)
+//	@Success		200	{object} domain.ActorRevision
+//	@Failure		400,404,500 {object} problem
+//	@Router			/api/v1/actors/{id}/revisions/{rev} [get]
+func (h *Handler) GetActorRevision(c *gin.Context) {
+	id, err := getIdFromParam(c, "id")
+	if err != nil {
+		handleBadRequest(c, "GetActorRevision", "failed reading request param", err)
+
+		return
+	}
+
+	rev, err := getIdFromParam(c, "rev")
+	if err != nil {
+		handleBadRequest(c, "GetActorRevision", "failed reading request param", err)
+
+		return
+	}
+
+	revision, err := h.actorsService.GetRevision(c.Request.Context(), id, rev)
+	if err != nil {
+		handleError(c, "GetActorRevision", err)
+
+		return
+	}
+
+	writeJSON(c, "GetActorRevision", &revision)
+}
+
+// Auth godoc
+//
+//	@Summary		Diff actor's revisions
+//	@Security 		ApiKeyAuth
+//	@Description	get the fields of the actor that differ between two revisions
+//	@Tags			actor
+//	@Produce		json
+//	@Param			id		path	int	true	"actor id"	minimum(1)
+//	@Param			from	query	int	true	"older revision"	minimum(1)
+//	@Param			to		query	int	true	"newer revision"	minimum(1)
+//	@Success		200	{object} domain.ActorRevisionsDiff
+//	@Failure		400,404,422,500 {object} problem
+//	@Router			/api/v1/actors/{id}/revisions/diff [get]
+func (h *Handler) DiffActorRevisions(c *gin.Context) {
+	id, err := getIdFromParam(c, "id")
+	if err != nil {
+		handleBadRequest(c, "DiffActorRevisions", "failed reading request param", err)
+
+		return
+	}
+
+	var input domain.RevisionsDiffInput
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		handleBadRequest(c, "DiffActorRevisions", "failed reading query params", err)
+
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		handleError(c, "DiffActorRevisions", err)
+
+		return
+	}
+
+	diff, err := h.actorsService.DiffRevisions(c.Request.Context(), id, input.From, input.To)
+	if err != nil {
+		handleError(c, "DiffActorRevisions", err)
+
+		return
+	}
+
+	writeJSON(c, "DiffActorRevisions", &diff)
+}
+
+// Auth godoc
+//
+//	@Summary		Revert actor to a revision
+//	@Security 		ApiKeyAuth
+//	@Description	set the actor back to the state of the revision, which adds a new revision
+//	@Tags			actor
+//	@Produce		json
+//	@Param			id	path	int	true	"actor id"	minimum(1)
+//	@Param			rev	path	int	true	"revision"	minimum(1)
+//	@Param			If-Match	header	string	true	"ETag of the actor, * for any version"
+//	@Success		200	{object} domain.Actor
+//	@Header			200	{string}	ETag	"new version of the actor"
+//	@Failure		400,401,403,404,409,412,422,428,500 {object} problem
+//	@Router			/api/v1/actors/{id}/revisions/{rev}/revert [post]
+func (h *Handler) RevertActor(c *gin.Context) {
+	id, err := getIdFromParam(c, "id")
+	if err != nil {
+		handleBadRequest(c, "RevertActor", "failed reading request param", err)
+
+		return
+	}
+
+	rev, err := getIdFromParam(c, "rev")
+	if err != nil {
+		handleBadRequest(c, "RevertActor", "failed reading request param", err)
+
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		handleError(c, "RevertActor", err)
+
+		return
+	}
+
+	actor, err := h.actorsService.Revert(c.Request.Context(), id, rev, version)
+	if err != nil {
+		handleError(c, "RevertActor", err)
+
+		return
+	}
+
+	c.Header("ETag", actorETag(actor.Version))
+	writeJSON(c, "RevertActor", &actor)
+}
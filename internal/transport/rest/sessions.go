@@ -0,0 +1,127 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// Auth godoc
+//
+//	@Summary		Logout
+//	@Security 		ApiKeyAuth
+//	@Description	end the current session and revoke its tokens
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{integer} integer 1
+//	@Failure		401,500 {object} problem
+//	@Router			/api/v1/auth/logout [post]
+func (h *Handler) Logout(c *gin.Context) {
+	claims, ok := getClaims(c, "Logout")
+	if !ok {
+		return
+	}
+
+	if err := h.usersService.Logout(c.Request.Context(), claims); err != nil {
+		handleError(c, "Logout", err)
+
+		return
+	}
+
+	c.Header("Set-Cookie", "refresh-token=; Max-Age=0; HttpOnly")
+	c.Writer.WriteHeader(http.StatusOK)
+}
+
+// Auth godoc
+//
+//	@Summary		Logout everywhere
+//	@Security 		ApiKeyAuth
+//	@Description	end all sessions of the current user
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{integer} integer 1
+//	@Failure		401,500 {object} problem
+//	@Router			/api/v1/auth/logout-all [post]
+func (h *Handler) LogoutAll(c *gin.Context) {
+	claims, ok := getClaims(c, "LogoutAll")
+	if !ok {
+		return
+	}
+
+	if err := h.usersService.LogoutAll(c.Request.Context(), claims); err != nil {
+		handleError(c, "LogoutAll", err)
+
+		return
+	}
+
+	c.Header("Set-Cookie", "refresh-token=; Max-Age=0; HttpOnly")
+	c.Writer.WriteHeader(http.StatusOK)
+}
+
+// Auth godoc
+//
+//	@Summary		Get sessions
+//	@Security 		ApiKeyAuth
+//	@Description	get active sessions (signed in devices) of the current user
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array} domain.Session
+//	@Failure		401,500 {object} problem
+//	@Router			/api/v1/me/sessions [get]
+func (h *Handler) GetMySessions(c *gin.Context) {
+	claims, ok := getClaims(c, "GetMySessions")
+	if !ok {
+		return
+	}
+
+	sessions, err := h.usersService.GetSessions(c.Request.Context(), claims)
+	if err != nil {
+		handleError(c, "GetMySessions", err)
+
+		return
+	}
+
+	writeJSON(c, "GetMySessions", &sessions)
+}
+
+// Auth godoc
+//
+//	@Summary		Revoke session
+//	@Security 		ApiKeyAuth
+//	@Description	end one of the sessions of the current user
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	string	true	"session id"
+//	@Success		200	{integer} integer 1
+//	@Failure		401,404,500 {object} problem
+//	@Router			/api/v1/me/sessions/{id} [delete]
+func (h *Handler) RevokeMySession(c *gin.Context) {
+	claims, ok := getClaims(c, "RevokeMySession")
+	if !ok {
+		return
+	}
+
+	if err := h.usersService.RevokeSession(c.Request.Context(), claims.UserID, c.Param("id")); err != nil {
+		handleError(c, "RevokeMySession", err)
+
+		return
+	}
+
+	c.Writer.WriteHeader(http.StatusOK)
+}
+
+func getClaims(c *gin.Context, handler string) (domain.TokenClaims, bool) {
+	claims, ok := domain.TokenClaimsFromContext(c.Request.Context())
+	if !ok {
+		handleError(c, handler, domain.ErrUnauthenticatedUser)
+
+		return claims, false
+	}
+
+	return claims, true
+}
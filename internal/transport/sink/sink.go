@@ -0,0 +1,87 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/AngelicaNice/HollywoodStarsCRUD/internal/domain"
+)
+
+// Sink receives audit events.
+type Sink interface {
+	Publish(ctx context.Context, event domain.Event) error
+}
+
+// Noop drops every event, for running without an audit log.
+type Noop struct{}
+
+func (Noop) Publish(ctx context.Context, event domain.Event) error {
+	return nil
+}
+
+// Fanout publishes every event to all of its sinks. If some of them fail the
+// event is reported as failed and will be published again to all of them.
+type Fanout []Sink
+
+func (f Fanout) Publish(ctx context.Context, event domain.Event) error {
+	errs := make([]error, 0)
+
+	for _, s := range f {
+		if err := s.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// File appends events to a file, one JSON document per line: the event in
+// the CloudEvents structured mode or, in the legacy format, its data only.
+type File struct {
+	mu     sync.Mutex
+	file   *os.File
+	legacy bool
+}
+
+func NewFile(path string, legacy bool) (*File, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{
+		file:   file,
+		legacy: legacy,
+	}, nil
+}
+
+func (f *File) Publish(ctx context.Context, event domain.Event) error {
+	body := []byte(event.Data)
+
+	if !f.legacy {
+		var err error
+
+		body, err = json.Marshal(event)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(body) == 0 || body[len(body)-1] != '\n' {
+		body = append(body, '\n')
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, err := f.file.Write(body)
+
+	return err
+}
+
+func (f *File) Close() error {
+	return f.file.Close()
+}
@@ -0,0 +1,286 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: actors.proto
+
+package actors
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ActorsService_Create_FullMethodName = "/actors.ActorsService/Create"
+	ActorsService_Get_FullMethodName    = "/actors.ActorsService/Get"
+	ActorsService_List_FullMethodName   = "/actors.ActorsService/List"
+	ActorsService_Update_FullMethodName = "/actors.ActorsService/Update"
+	ActorsService_Delete_FullMethodName = "/actors.ActorsService/Delete"
+)
+
+// ActorsServiceClient is the client API for ActorsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ActorsServiceClient interface {
+	Create(ctx context.Context, in *CreateActorRequest, opts ...grpc.CallOption) (*CreateActorResponse, error)
+	Get(ctx context.Context, in *GetActorRequest, opts ...grpc.CallOption) (*Actor, error)
+	List(ctx context.Context, in *ListActorsRequest, opts ...grpc.CallOption) (ActorsService_ListClient, error)
+	Update(ctx context.Context, in *UpdateActorRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Delete(ctx context.Context, in *DeleteActorRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type actorsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewActorsServiceClient(cc grpc.ClientConnInterface) ActorsServiceClient {
+	return &actorsServiceClient{cc}
+}
+
+func (c *actorsServiceClient) Create(ctx context.Context, in *CreateActorRequest, opts ...grpc.CallOption) (*CreateActorResponse, error) {
+	out := new(CreateActorResponse)
+	err := c.cc.Invoke(ctx, ActorsService_Create_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *actorsServiceClient) Get(ctx context.Context, in *GetActorRequest, opts ...grpc.CallOption) (*Actor, error) {
+	out := new(Actor)
+	err := c.cc.Invoke(ctx, ActorsService_Get_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *actorsServiceClient) List(ctx context.Context, in *ListActorsRequest, opts ...grpc.CallOption) (ActorsService_ListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ActorsService_ServiceDesc.Streams[0], ActorsService_List_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &actorsServiceListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ActorsService_ListClient interface {
+	Recv() (*Actor, error)
+	grpc.ClientStream
+}
+
+type actorsServiceListClient struct {
+	grpc.ClientStream
+}
+
+func (x *actorsServiceListClient) Recv() (*Actor, error) {
+	m := new(Actor)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *actorsServiceClient) Update(ctx context.Context, in *UpdateActorRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ActorsService_Update_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *actorsServiceClient) Delete(ctx context.Context, in *DeleteActorRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ActorsService_Delete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ActorsServiceServer is the server API for ActorsService service.
+// All implementations must embed UnimplementedActorsServiceServer
+// for forward compatibility
+type ActorsServiceServer interface {
+	Create(context.Context, *CreateActorRequest) (*CreateActorResponse, error)
+	Get(context.Context, *GetActorRequest) (*Actor, error)
+	List(*ListActorsRequest, ActorsService_ListServer) error
+	Update(context.Context, *UpdateActorRequest) (*emptypb.Empty, error)
+	Delete(context.Context, *DeleteActorRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedActorsServiceServer()
+}
+
+// UnimplementedActorsServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedActorsServiceServer struct {
+}
+
+func (UnimplementedActorsServiceServer) Create(context.Context, *CreateActorRequest) (*CreateActorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedActorsServiceServer) Get(context.Context, *GetActorRequest) (*Actor, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedActorsServiceServer) List(*ListActorsRequest, ActorsService_ListServer) error {
+	return status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedActorsServiceServer) Update(context.Context, *UpdateActorRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedActorsServiceServer) Delete(context.Context, *DeleteActorRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedActorsServiceServer) mustEmbedUnimplementedActorsServiceServer() {}
+
+// UnsafeActorsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ActorsServiceServer will
+// result in compilation errors.
+type UnsafeActorsServiceServer interface {
+	mustEmbedUnimplementedActorsServiceServer()
+}
+
+func RegisterActorsServiceServer(s grpc.ServiceRegistrar, srv ActorsServiceServer) {
+	s.RegisterService(&ActorsService_ServiceDesc, srv)
+}
+
+func _ActorsService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateActorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActorsServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ActorsService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActorsServiceServer).Create(ctx, req.(*CreateActorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ActorsService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetActorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActorsServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ActorsService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActorsServiceServer).Get(ctx, req.(*GetActorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ActorsService_List_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListActorsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ActorsServiceServer).List(m, &actorsServiceListServer{stream})
+}
+
+type ActorsService_ListServer interface {
+	Send(*Actor) error
+	grpc.ServerStream
+}
+
+type actorsServiceListServer struct {
+	grpc.ServerStream
+}
+
+func (x *actorsServiceListServer) Send(m *Actor) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ActorsService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateActorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActorsServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ActorsService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActorsServiceServer).Update(ctx, req.(*UpdateActorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ActorsService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteActorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActorsServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ActorsService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActorsServiceServer).Delete(ctx, req.(*DeleteActorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ActorsService_ServiceDesc is the grpc.ServiceDesc for ActorsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ActorsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "actors.ActorsService",
+	HandlerType: (*ActorsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _ActorsService_Create_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _ActorsService_Get_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _ActorsService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _ActorsService_Delete_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "List",
+			Handler:       _ActorsService_List_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "actors.proto",
+}
@@ -0,0 +1,144 @@
+package hash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+var ErrInvalidHash = errors.New("invalid password hash")
+
+// Argon2Params are the argon2id cost parameters, see RFC 9106.
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+var DefaultArgon2Params = Argon2Params{
+	Memory:      64 * 1024,
+	Iterations:  1,
+	Parallelism: 4,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// LegacyHasher produces hashes stored before argon2id was introduced.
+type LegacyHasher interface {
+	Hash(password string) (string, error)
+}
+
+// Argon2Hasher hashes passwords with argon2id and a random per-password salt,
+// encoded in the PHC string format
+// $argon2id$v=19$m=65536,t=1,p=4$<salt>$<key>.
+type Argon2Hasher struct {
+	params Argon2Params
+	legacy LegacyHasher
+}
+
+// NewArgon2Hasher creates a hasher which also verifies hashes produced by
+// legacy, reporting them as needing a rehash. legacy may be nil.
+func NewArgon2Hasher(p Argon2Params, legacy LegacyHasher) *Argon2Hasher {
+	return &Argon2Hasher{
+		params: p,
+		legacy: legacy,
+	}
+}
+
+func (hasher Argon2Hasher) Hash(password string) (string, error) {
+	salt := make([]byte, hasher.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, hasher.params.Iterations, hasher.params.Memory,
+		hasher.params.Parallelism, hasher.params.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version,
+		hasher.params.Memory, hasher.params.Iterations, hasher.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify compares password with the encoded hash in constant time. needsRehash
+// is true when the hash matched but was made by the legacy hasher or with
+// other argon2id parameters than the current ones.
+func (hasher Argon2Hasher) Verify(password, encoded string) (ok bool, needsRehash bool, err error) {
+	if !strings.HasPrefix(encoded, "$argon2id$") {
+		return hasher.verifyLegacy(password, encoded)
+	}
+
+	p, salt, key, err := decodeArgon2Hash(encoded)
+	if err != nil {
+		return false, false, err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return false, false, nil
+	}
+
+	needsRehash = p.Memory != hasher.params.Memory ||
+		p.Iterations != hasher.params.Iterations ||
+		p.Parallelism != hasher.params.Parallelism ||
+		p.KeyLength != hasher.params.KeyLength ||
+		p.SaltLength != hasher.params.SaltLength
+
+	return true, needsRehash, nil
+}
+
+func (hasher Argon2Hasher) verifyLegacy(password, encoded string) (bool, bool, error) {
+	if hasher.legacy == nil {
+		return false, false, ErrInvalidHash
+	}
+
+	other, err := hasher.legacy.Hash(password)
+	if err != nil {
+		return false, false, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(encoded), []byte(other)) != 1 {
+		return false, false, nil
+	}
+
+	return true, true, nil
+}
+
+func decodeArgon2Hash(encoded string) (Argon2Params, []byte, []byte, error) {
+	var p Argon2Params
+
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return p, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, ErrInvalidHash
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil {
+		return p, nil, nil, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return p, nil, nil, ErrInvalidHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return p, nil, nil, ErrInvalidHash
+	}
+
+	p.SaltLength = uint32(len(salt))
+	p.KeyLength = uint32(len(key))
+
+	return p, salt, key, nil
+}
@@ -0,0 +1,114 @@
+package hash
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fastParams keep the tests quick, the encoding is the same as with
+// DefaultArgon2Params.
+var fastParams = Argon2Params{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func mustHash(t *testing.T, hasher *Argon2Hasher, password string) string {
+	t.Helper()
+
+	encoded, err := hasher.Hash(password)
+	if err != nil {
+		t.Fatalf("Hash(%q): %v", password, err)
+	}
+
+	return encoded
+}
+
+func TestHashUsesPHCFormatAndRandomSalt(t *testing.T) {
+	hasher := NewArgon2Hasher(fastParams, nil)
+
+	first := mustHash(t, hasher, "secret")
+	if !strings.HasPrefix(first, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Errorf("Hash() = %q, not in the PHC string format", first)
+	}
+
+	if second := mustHash(t, hasher, "secret"); second == first {
+		t.Error("two hashes of the same password are equal, the salt isn't random")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	hasher := NewArgon2Hasher(fastParams, nil)
+	encoded := mustHash(t, hasher, "secret")
+
+	ok, needsRehash, err := hasher.Verify("secret", encoded)
+	if err != nil || !ok || needsRehash {
+		t.Errorf("Verify(right password) = %v, %v, %v; want true, false, nil", ok, needsRehash, err)
+	}
+
+	ok, needsRehash, err = hasher.Verify("Secret", encoded)
+	if err != nil || ok || needsRehash {
+		t.Errorf("Verify(wrong password) = %v, %v, %v; want false, false, nil", ok, needsRehash, err)
+	}
+}
+
+func TestVerifyAsksToRehashOutdatedParams(t *testing.T) {
+	old := NewArgon2Hasher(Argon2Params{
+		Memory:      2048,
+		Iterations:  2,
+		Parallelism: 1,
+		SaltLength:  8,
+		KeyLength:   16,
+	}, nil)
+	encoded := mustHash(t, old, "secret")
+
+	ok, needsRehash, err := NewArgon2Hasher(fastParams, nil).Verify("secret", encoded)
+	if err != nil || !ok || !needsRehash {
+		t.Errorf("Verify() = %v, %v, %v; want true, true, nil", ok, needsRehash, err)
+	}
+}
+
+func TestVerifyLegacyHash(t *testing.T) {
+	legacy := NewSHA1Hasher("salt")
+
+	encoded, err := legacy.Hash("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hasher := NewArgon2Hasher(fastParams, legacy)
+
+	ok, needsRehash, err := hasher.Verify("secret", encoded)
+	if err != nil || !ok || !needsRehash {
+		t.Errorf("Verify(right password) = %v, %v, %v; want true, true, nil", ok, needsRehash, err)
+	}
+
+	ok, needsRehash, err = hasher.Verify("Secret", encoded)
+	if err != nil || ok || needsRehash {
+		t.Errorf("Verify(wrong password) = %v, %v, %v; want false, false, nil", ok, needsRehash, err)
+	}
+
+	if _, _, err := NewArgon2Hasher(fastParams, nil).Verify("secret", encoded); !errors.Is(err, ErrInvalidHash) {
+		t.Errorf("Verify() without a legacy hasher: %v, want %v", err, ErrInvalidHash)
+	}
+}
+
+func TestVerifyRejectsMalformedHashes(t *testing.T) {
+	hasher := NewArgon2Hasher(fastParams, nil)
+	encoded := mustHash(t, hasher, "secret")
+
+	for _, malformed := range []string{
+		"$argon2id$v=19$m=1024,t=1,p=1$c2FsdA",
+		strings.Replace(encoded, "v=19", "v=16", 1),
+		strings.Replace(encoded, "m=1024", "m=x", 1),
+		"$argon2id$v=19$m=1024,t=1,p=1$!!!$c2FsdA",
+		"$argon2id$v=19$m=1024,t=1,p=1$c2FsdA$!!!",
+	} {
+		if _, _, err := hasher.Verify("secret", malformed); !errors.Is(err, ErrInvalidHash) {
+			t.Errorf("Verify(%q): %v, want %v", malformed, err, ErrInvalidHash)
+		}
+	}
+}
@@ -0,0 +1,382 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidPatch = errors.New("invalid patch")
+	ErrPathNotFound = errors.New("path not found")
+	ErrTestFailed   = errors.New("test operation failed")
+)
+
+// MergePatch applies an RFC 7396 merge patch to doc: members of patch replace
+// the ones of doc, null members remove them and objects are merged
+// recursively.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	var d, p interface{}
+
+	if err := json.Unmarshal(doc, &d); err != nil {
+		return nil, err
+	}
+
+	if err := unmarshal(patch, &p); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(mergePatch(d, p))
+}
+
+func mergePatch(doc, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	d, ok := doc.(map[string]interface{})
+	if !ok {
+		d = make(map[string]interface{}, len(p))
+	}
+
+	for k, v := range p {
+		if v == nil {
+			delete(d, k)
+
+			continue
+		}
+
+		d[k] = mergePatch(d[k], v)
+	}
+
+	return d
+}
+
+// Operation is an RFC 6902 operation, From is used by move and copy, Value
+// by add, replace and test. Value is empty when the member is absent and
+// holds null when the value is an explicit null.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Apply applies the RFC 6902 patch, a JSON array of operations, to doc. The
+// operations are applied in order and if one fails none are.
+func Apply(doc, patch []byte) ([]byte, error) {
+	var ops []Operation
+
+	if err := unmarshal(patch, &ops); err != nil {
+		return nil, err
+	}
+
+	var d interface{}
+
+	if err := json.Unmarshal(doc, &d); err != nil {
+		return nil, err
+	}
+
+	for i, op := range ops {
+		var err error
+
+		if d, err = apply(d, op); err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+
+	return json.Marshal(d)
+}
+
+func apply(doc interface{}, op Operation) (interface{}, error) {
+	switch op.Op {
+	case "add", "replace", "test":
+		if len(op.Value) == 0 {
+			return nil, fmt.Errorf("%w: missing value", ErrInvalidPatch)
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPatch, err)
+		}
+
+		switch op.Op {
+		case "add":
+			return add(doc, op.Path, value)
+		case "replace":
+			return replace(doc, op.Path, value)
+		default:
+			current, err := get(doc, op.Path)
+			if err != nil {
+				return nil, err
+			}
+
+			if !reflect.DeepEqual(current, value) {
+				return nil, ErrTestFailed
+			}
+
+			return doc, nil
+		}
+	case "remove":
+		return remove(doc, op.Path)
+	case "move", "copy":
+		value, err := get(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+
+		if op.Op == "move" {
+			if strings.HasPrefix(op.Path, op.From+"/") {
+				return nil, fmt.Errorf("%w: can't move a value into itself", ErrInvalidPatch)
+			}
+
+			if doc, err = remove(doc, op.From); err != nil {
+				return nil, err
+			}
+		} else {
+			value = deepCopy(value)
+		}
+
+		return add(doc, op.Path, value)
+	}
+
+	return nil, fmt.Errorf("%w: unknown operation %q", ErrInvalidPatch, op.Op)
+}
+
+// get returns the value the JSON pointer path points at.
+func get(doc interface{}, path string) (interface{}, error) {
+	tokens, err := parsePointer(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, token := range tokens {
+		switch v := doc.(type) {
+		case map[string]interface{}:
+			child, ok := v[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+			}
+
+			doc = child
+		case []interface{}:
+			i, err := arrayIndex(token, len(v)-1)
+			if err != nil {
+				return nil, err
+			}
+
+			doc = v[i]
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+		}
+	}
+
+	return doc, nil
+}
+
+func add(doc interface{}, path string, value interface{}) (interface{}, error) {
+	if path == "" {
+		return value, nil
+	}
+
+	return update(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch v := parent.(type) {
+		case map[string]interface{}:
+			v[token] = value
+
+			return v, nil
+		case []interface{}:
+			if token == "-" {
+				return append(v, value), nil
+			}
+
+			i, err := arrayIndex(token, len(v))
+			if err != nil {
+				return nil, err
+			}
+
+			v = append(v, nil)
+			copy(v[i+1:], v[i:])
+			v[i] = value
+
+			return v, nil
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+	})
+}
+
+func replace(doc interface{}, path string, value interface{}) (interface{}, error) {
+	if path == "" {
+		return value, nil
+	}
+
+	return update(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch v := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := v[token]; !ok {
+				return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+			}
+
+			v[token] = value
+
+			return v, nil
+		case []interface{}:
+			i, err := arrayIndex(token, len(v)-1)
+			if err != nil {
+				return nil, err
+			}
+
+			v[i] = value
+
+			return v, nil
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+	})
+}
+
+func remove(doc interface{}, path string) (interface{}, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%w: can't remove the whole document", ErrInvalidPatch)
+	}
+
+	return update(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch v := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := v[token]; !ok {
+				return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+			}
+
+			delete(v, token)
+
+			return v, nil
+		case []interface{}:
+			i, err := arrayIndex(token, len(v)-1)
+			if err != nil {
+				return nil, err
+			}
+
+			return append(v[:i], v[i+1:]...), nil
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+	})
+}
+
+// update calls fn with the container the last token of the non empty path
+// refers to and puts the container fn returns back into doc.
+func update(doc interface{}, path string, fn func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	tokens, err := parsePointer(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var walk func(node interface{}, tokens []string) (interface{}, error)
+
+	walk = func(node interface{}, tokens []string) (interface{}, error) {
+		if len(tokens) == 1 {
+			return fn(node, tokens[0])
+		}
+
+		switch v := node.(type) {
+		case map[string]interface{}:
+			child, ok := v[tokens[0]]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+			}
+
+			updated, err := walk(child, tokens[1:])
+			if err != nil {
+				return nil, err
+			}
+
+			v[tokens[0]] = updated
+
+			return v, nil
+		case []interface{}:
+			i, err := arrayIndex(tokens[0], len(v)-1)
+			if err != nil {
+				return nil, err
+			}
+
+			updated, err := walk(v[i], tokens[1:])
+			if err != nil {
+				return nil, err
+			}
+
+			v[i] = updated
+
+			return v, nil
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+	}
+
+	return walk(doc, tokens)
+}
+
+// parsePointer splits an RFC 6901 JSON pointer into unescaped tokens.
+func parsePointer(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("%w: bad pointer %q", ErrInvalidPatch, path)
+	}
+
+	tokens := strings.Split(path[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+func arrayIndex(token string, max int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("%w: bad array index %q", ErrInvalidPatch, token)
+	}
+
+	if i > max {
+		return 0, fmt.Errorf("%w: index %d", ErrPathNotFound, i)
+	}
+
+	return i, nil
+}
+
+func deepCopy(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for k, child := range v {
+			res[k] = deepCopy(child)
+		}
+
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, child := range v {
+			res[i] = deepCopy(child)
+		}
+
+		return res
+	}
+
+	return v
+}
+
+// unmarshal decodes a patch, reporting malformed JSON as ErrInvalidPatch.
+func unmarshal(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidPatch, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt"
+)
+
+var (
+	ErrUnknownKey       = errors.New("unknown signing key")
+	ErrUnsupportedAlg   = errors.New("unsupported signing algorithm")
+	ErrNoSigningKey     = errors.New("signing key has no private part")
+	ErrMissingKeyFile   = errors.New("neither private nor public key file is set")
+	ErrKeyAlgorithmType = errors.New("key doesn't match the algorithm")
+)
+
+// Key is a key identified by kid. Keys without a private part are only used
+// to verify tokens issued before a rotation.
+type Key struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private interface{}
+	Public  interface{}
+}
+
+// KeySet holds the key new tokens are signed with and every key tokens are
+// still verified with.
+type KeySet struct {
+	signing Key
+	keys    map[string]Key
+	order   []string
+}
+
+func NewKeySet(signingKid string, keys ...Key) (*KeySet, error) {
+	ks := &KeySet{
+		keys: make(map[string]Key, len(keys)),
+	}
+
+	for _, k := range keys {
+		if _, ok := ks.keys[k.ID]; ok {
+			return nil, fmt.Errorf("duplicate key id %q", k.ID)
+		}
+
+		ks.keys[k.ID] = k
+		ks.order = append(ks.order, k.ID)
+	}
+
+	signing, ok := ks.keys[signingKid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, signingKid)
+	}
+
+	if signing.Private == nil {
+		return nil, fmt.Errorf("%w: %q", ErrNoSigningKey, signingKid)
+	}
+
+	ks.signing = signing
+
+	return ks, nil
+}
+
+// SigningKey returns the key new tokens have to be signed with.
+func (ks *KeySet) SigningKey() Key {
+	return ks.signing
+}
+
+// Key returns the key with the kid.
+func (ks *KeySet) Key(kid string) (Key, error) {
+	k, ok := ks.keys[kid]
+	if !ok {
+		return Key{}, fmt.Errorf("%w: %q", ErrUnknownKey, kid)
+	}
+
+	return k, nil
+}
+
+// LoadKey reads PEM encoded keys for alg (RS256, RS384, RS512 or EdDSA). If
+// privateFile is empty, the key is loaded for verification only.
+func LoadKey(kid, alg, privateFile, publicFile string) (Key, error) {
+	method := jwt.GetSigningMethod(alg)
+
+	switch method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodEd25519:
+	default:
+		return Key{}, fmt.Errorf("%w: %q", ErrUnsupportedAlg, alg)
+	}
+
+	k := Key{ID: kid, Method: method}
+
+	switch {
+	case privateFile != "":
+		pem, err := os.ReadFile(privateFile)
+		if err != nil {
+			return Key{}, err
+		}
+
+		if err := k.parsePrivate(pem); err != nil {
+			return Key{}, fmt.Errorf("key %q: %w", kid, err)
+		}
+	case publicFile != "":
+		pem, err := os.ReadFile(publicFile)
+		if err != nil {
+			return Key{}, err
+		}
+
+		if err := k.parsePublic(pem); err != nil {
+			return Key{}, fmt.Errorf("key %q: %w", kid, err)
+		}
+	default:
+		return Key{}, fmt.Errorf("key %q: %w", kid, ErrMissingKeyFile)
+	}
+
+	return k, nil
+}
+
+func (k *Key) parsePrivate(pem []byte) error {
+	switch k.Method.(type) {
+	case *jwt.SigningMethodRSA:
+		priv, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+		if err != nil {
+			return err
+		}
+
+		k.Private, k.Public = priv, &priv.PublicKey
+	default:
+		priv, err := jwt.ParseEdPrivateKeyFromPEM(pem)
+		if err != nil {
+			return err
+		}
+
+		edPriv, ok := priv.(ed25519.PrivateKey)
+		if !ok {
+			return ErrKeyAlgorithmType
+		}
+
+		k.Private, k.Public = edPriv, edPriv.Public()
+	}
+
+	return nil
+}
+
+func (k *Key) parsePublic(pem []byte) error {
+	switch k.Method.(type) {
+	case *jwt.SigningMethodRSA:
+		pub, err := jwt.ParseRSAPublicKeyFromPEM(pem)
+		if err != nil {
+			return err
+		}
+
+		k.Public = pub
+	default:
+		pub, err := jwt.ParseEdPublicKeyFromPEM(pem)
+		if err != nil {
+			return err
+		}
+
+		k.Public = pub
+	}
+
+	return nil
+}
+
+// GenerateEd25519Key creates a random key which lives as long as the process.
+// Tokens signed with it can't be verified after a restart.
+func GenerateEd25519Key(kid string) (Key, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return Key{}, err
+	}
+
+	return Key{
+		ID:      kid,
+		Method:  jwt.SigningMethodEdDSA,
+		Private: priv,
+		Public:  pub,
+	}, nil
+}
+
+// JWK is a public key in the JSON Web Key format, see RFC 7517.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public parts of all keys in the set.
+func (ks *KeySet) JWKS() JWKS {
+	set := JWKS{Keys: make([]JWK, 0, len(ks.order))}
+
+	for _, kid := range ks.order {
+		k := ks.keys[kid]
+		jwk := JWK{Kid: k.ID, Use: "sig", Alg: k.Method.Alg()}
+
+		switch pub := k.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set
+}
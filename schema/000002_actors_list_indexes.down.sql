@@ -0,0 +1,3 @@
+DROP INDEX actors_birth_year_id_idx;
+DROP INDEX actors_surname_id_idx;
+DROP INDEX actors_name_id_idx;
@@ -0,0 +1,5 @@
+CREATE INDEX actors_name_id_idx ON actors (name, id);
+
+CREATE INDEX actors_surname_id_idx ON actors (surname, id);
+
+CREATE INDEX actors_birth_year_id_idx ON actors (birth_year, id);
@@ -0,0 +1,4 @@
+DROP INDEX actors_search_trgm_idx;
+DROP INDEX actors_search_vector_idx;
+
+ALTER TABLE actors DROP COLUMN search_vector;
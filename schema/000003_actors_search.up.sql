@@ -0,0 +1,8 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE actors ADD COLUMN search_vector tsvector
+  GENERATED ALWAYS AS (to_tsvector('simple', name || ' ' || surname || ' ' || birth_place)) STORED;
+
+CREATE INDEX actors_search_vector_idx ON actors USING gin (search_vector);
+
+CREATE INDEX actors_search_trgm_idx ON actors USING gin ((name || ' ' || surname || ' ' || birth_place) gin_trgm_ops);
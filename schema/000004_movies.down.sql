@@ -0,0 +1,2 @@
+DROP TABLE actor_roles;
+DROP TABLE movies;
@@ -0,0 +1,28 @@
+CREATE TABLE movies (
+  id           serial       NOT NULL UNIQUE,
+  title        varchar(100) NOT NULL,
+  release_year integer      NOT NULL,
+  genres       text[]       NOT NULL DEFAULT '{}',
+  runtime      integer,
+  studio       varchar(50)
+);
+
+CREATE TABLE actor_roles (
+  id             serial       NOT NULL UNIQUE,
+  actor_id       integer      NOT NULL,
+  movie_id       integer      NOT NULL,
+  character_name varchar(100) NOT NULL,
+  billing_order  integer,
+  role_type      varchar(10)  NOT NULL,
+  UNIQUE (actor_id, movie_id, character_name)
+);
+
+CREATE INDEX ON movies (title);
+
+CREATE INDEX ON actor_roles (movie_id);
+
+ALTER TABLE actor_roles ADD CONSTRAINT actor_roles_actor_id_fkey
+  FOREIGN KEY (actor_id) REFERENCES actors (id) ON DELETE CASCADE;
+
+ALTER TABLE actor_roles ADD CONSTRAINT actor_roles_movie_id_fkey
+  FOREIGN KEY (movie_id) REFERENCES movies (id) ON DELETE CASCADE;
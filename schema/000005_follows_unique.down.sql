@@ -0,0 +1,3 @@
+DROP INDEX follows_followed_actor_id_idx;
+
+ALTER TABLE follows DROP CONSTRAINT follows_user_actor_key;
@@ -0,0 +1,3 @@
+ALTER TABLE follows ADD CONSTRAINT follows_user_actor_key UNIQUE (following_user_id, followed_actor_id);
+
+CREATE INDEX ON follows (followed_actor_id);
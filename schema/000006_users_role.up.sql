@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN role varchar(10) NOT NULL DEFAULT 'viewer';
+
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('viewer', 'editor', 'admin'));
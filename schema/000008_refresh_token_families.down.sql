@@ -0,0 +1,15 @@
+DELETE FROM refresh_tokens;
+
+DROP INDEX refresh_tokens_family_id_idx;
+
+ALTER TABLE refresh_tokens DROP COLUMN revoked_at;
+
+ALTER TABLE refresh_tokens DROP COLUMN rotated_at;
+
+ALTER TABLE refresh_tokens DROP COLUMN created_at;
+
+ALTER TABLE refresh_tokens DROP COLUMN family_id;
+
+ALTER TABLE refresh_tokens ALTER COLUMN token_hash TYPE varchar(255);
+
+ALTER TABLE refresh_tokens RENAME COLUMN token_hash TO token;
@@ -0,0 +1,19 @@
+ALTER TABLE refresh_tokens RENAME COLUMN token TO token_hash;
+
+UPDATE refresh_tokens SET token_hash = encode(sha256(token_hash::bytea), 'hex');
+
+ALTER TABLE refresh_tokens ALTER COLUMN token_hash TYPE varchar(64);
+
+ALTER TABLE refresh_tokens ADD COLUMN family_id varchar(32);
+
+UPDATE refresh_tokens SET family_id = md5(token_hash);
+
+ALTER TABLE refresh_tokens ALTER COLUMN family_id SET NOT NULL;
+
+ALTER TABLE refresh_tokens ADD COLUMN created_at timestamp NOT NULL DEFAULT (now());
+
+ALTER TABLE refresh_tokens ADD COLUMN rotated_at timestamp;
+
+ALTER TABLE refresh_tokens ADD COLUMN revoked_at timestamp;
+
+CREATE INDEX ON refresh_tokens (family_id);
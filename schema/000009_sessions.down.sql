@@ -0,0 +1,5 @@
+DROP TABLE revoked_access_tokens;
+
+ALTER TABLE refresh_tokens DROP CONSTRAINT refresh_tokens_family_id_fkey;
+
+DROP TABLE sessions;
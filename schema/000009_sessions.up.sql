@@ -0,0 +1,25 @@
+CREATE TABLE sessions (
+  id           varchar(32)  NOT NULL UNIQUE,
+  user_id      int references users (id) on delete cascade not null,
+  device       varchar(50),
+  ip           varchar(45),
+  user_agent   varchar(255),
+  created_at   timestamp    NOT NULL DEFAULT (now()),
+  last_used_at timestamp    NOT NULL DEFAULT (now()),
+  revoked_at   timestamp
+);
+
+INSERT INTO sessions (id, user_id, created_at, last_used_at, revoked_at)
+  SELECT family_id, user_id, min(created_at), max(created_at),
+    CASE WHEN bool_and(revoked_at IS NOT NULL) THEN max(revoked_at) END
+  FROM refresh_tokens
+  GROUP BY family_id, user_id;
+
+CREATE INDEX ON sessions (user_id);
+
+ALTER TABLE refresh_tokens ADD FOREIGN KEY (family_id) REFERENCES sessions (id) ON DELETE CASCADE;
+
+CREATE TABLE revoked_access_tokens (
+  jti        varchar(32) NOT NULL UNIQUE,
+  expires_at timestamp   NOT NULL
+);
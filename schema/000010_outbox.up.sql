@@ -0,0 +1,11 @@
+CREATE TABLE outbox (
+  id              bigserial NOT NULL UNIQUE,
+  payload         bytea     NOT NULL,
+  created_at      timestamp NOT NULL DEFAULT (now()),
+  next_attempt_at timestamp NOT NULL DEFAULT (now()),
+  attempts        integer   NOT NULL DEFAULT 0,
+  last_error      text,
+  sent_at         timestamp
+);
+
+CREATE INDEX outbox_pending_idx ON outbox (next_attempt_at) WHERE sent_at IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE outbox ADD COLUMN routing_key varchar(255) NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE outbox ADD COLUMN subject varchar(255) NOT NULL DEFAULT '';
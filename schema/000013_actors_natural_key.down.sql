@@ -0,0 +1 @@
+DROP INDEX actors_natural_key_idx;
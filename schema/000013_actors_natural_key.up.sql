@@ -0,0 +1,25 @@
+-- Imports identify actors by name, surname and birth year. Actors already
+-- sharing them may be different people, so they aren't merged here: the
+-- migration fails listing them and an admin has to rename, merge or delete
+-- them before running it again.
+DO $$
+DECLARE
+  duplicates text;
+BEGIN
+  SELECT string_agg(format('%s %s (%s): ids %s', name, surname, birth_year, ids), '; ')
+  INTO duplicates
+  FROM (
+    SELECT name, surname, birth_year, string_agg(id::text, ', ' ORDER BY id) AS ids
+    FROM actors
+    GROUP BY name, surname, birth_year
+    HAVING count(*) > 1
+  ) d;
+
+  IF duplicates IS NOT NULL THEN
+    RAISE EXCEPTION 'actors with the same name, surname and birth year: %', duplicates
+      USING HINT = 'make them distinct or remove the extra ones, then run the migration again';
+  END IF;
+END
+$$;
+
+CREATE UNIQUE INDEX actors_natural_key_idx ON actors (name, surname, birth_year);
@@ -0,0 +1 @@
+ALTER TABLE actors DROP COLUMN version;
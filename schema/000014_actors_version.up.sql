@@ -0,0 +1,2 @@
+-- Bumped on every change, sent as the ETag of the actor.
+ALTER TABLE actors ADD COLUMN version bigint NOT NULL DEFAULT 1;
@@ -0,0 +1,11 @@
+ALTER TABLE follows DROP CONSTRAINT follows_followed_actor_id_fkey;
+ALTER TABLE follows ADD FOREIGN KEY (followed_actor_id) REFERENCES actors (id);
+
+-- Actors in the trash are lost.
+DELETE FROM actors WHERE deleted_at IS NOT NULL;
+
+DROP INDEX actors_natural_key_idx;
+CREATE UNIQUE INDEX actors_natural_key_idx ON actors (name, surname, birth_year);
+
+DROP INDEX actors_deleted_at_idx;
+ALTER TABLE actors DROP COLUMN deleted_at;
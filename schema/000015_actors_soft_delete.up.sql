@@ -0,0 +1,13 @@
+-- Deleted actors stay in the trash until they are restored or purged.
+ALTER TABLE actors ADD COLUMN deleted_at timestamp;
+
+CREATE INDEX actors_deleted_at_idx ON actors (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- A deleted actor doesn't block creating the actor again, restoring it does
+-- until the new one is deleted.
+DROP INDEX actors_natural_key_idx;
+CREATE UNIQUE INDEX actors_natural_key_idx ON actors (name, surname, birth_year) WHERE deleted_at IS NULL;
+
+-- Purging an actor removes its followers.
+ALTER TABLE follows DROP CONSTRAINT follows_followed_actor_id_fkey;
+ALTER TABLE follows ADD FOREIGN KEY (followed_actor_id) REFERENCES actors (id) ON DELETE CASCADE;
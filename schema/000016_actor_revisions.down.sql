@@ -0,0 +1 @@
+DROP TABLE actor_revisions;
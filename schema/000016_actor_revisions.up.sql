@@ -0,0 +1,22 @@
+-- Every change of an actor is stored as a revision holding the full actor after
+-- the change. The revision is the version of the actor the change produced.
+-- Revisions are never changed or deleted, actor_id isn't a foreign key so the
+-- history outlives a purged actor.
+CREATE TABLE actor_revisions (
+  actor_id   integer     NOT NULL,
+  revision   bigint      NOT NULL,
+  action     varchar(16) NOT NULL,
+  actor      jsonb       NOT NULL,
+  user_id    integer,
+  created_at timestamp   NOT NULL DEFAULT (now()),
+  PRIMARY KEY (actor_id, revision)
+);
+
+ALTER TABLE actor_revisions ADD FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL;
+
+-- The history of the existing actors starts with their current state.
+INSERT INTO actor_revisions (actor_id, revision, action, actor)
+SELECT id, version, 'baseline', jsonb_build_object(
+  'name', name, 'surname', surname, 'sex', sex, 'birth_year', birth_year,
+  'birth_place', birth_place, 'rest_year', rest_year, 'language', language)
+FROM actors;